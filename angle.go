@@ -1,9 +1,13 @@
 package dms
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
+
+	"github.com/blackchip-org/scan"
 )
 
 type Unit int
@@ -12,18 +16,73 @@ const (
 	DegUnit Unit = iota
 	MinUnit
 	SecUnit
+	GradUnit
+	MilUnit
+	TurnUnit
+	RadUnit
 )
 
 const pi180 = math.Pi / 180.0
 
-type axis int
+type Axis int
 
 const (
-	NoAxis axis = iota
+	NoAxis Axis = iota
 	LatAxis
 	LonAxis
 )
 
+func (a Axis) String() string {
+	switch a {
+	case LatAxis:
+		return "latitude"
+	case LonAxis:
+		return "longitude"
+	}
+	return "angle"
+}
+
+func (a Axis) Limit() float64 {
+	switch a {
+	case LatAxis:
+		return 90
+	case LonAxis:
+		return 180
+	}
+	return math.Inf(1)
+}
+
+// inRange returns true if the magnitude of the angle is within the limit
+// of the axis
+func (a Axis) inRange(an Angle) bool {
+	limit := a.Limit()
+	return math.IsInf(limit, 1) || new(big.Rat).Abs(an.rat()).Cmp(ratFloat(limit)) <= 0
+}
+
+func (a Axis) rangeError(v string) string {
+	return fmt.Sprintf("%v out of range: %v", a, scan.Quote(v))
+}
+
+func hemiAxis(h string) Axis {
+	switch h {
+	case NorthType, SouthType:
+		return LatAxis
+	case EastType, WestType:
+		return LonAxis
+	}
+	return NoAxis
+}
+
+func otherAxis(a Axis) Axis {
+	switch a {
+	case LatAxis:
+		return LonAxis
+	case LonAxis:
+		return LatAxis
+	}
+	return NoAxis
+}
+
 func Sign(v string) int {
 	switch v {
 	case SouthType, WestType, "-":
@@ -50,7 +109,7 @@ func HemiLon(sign int) string {
 	return WestType
 }
 
-func hemi(a axis, sign int) string {
+func hemi(a Axis, sign int) string {
 	switch a {
 	case LatAxis:
 		return HemiLat(sign)
@@ -68,6 +127,9 @@ type Fields struct {
 	MinSym string
 	SecSym string
 	Hemi   string
+	Hours  bool
+	Unit   Unit
+	Sep    string
 }
 
 func (f Fields) IsDD() bool {
@@ -89,6 +151,25 @@ func (f Fields) String() string {
 	if f.Hemi == "-" || f.Hemi == "+" {
 		sign = f.Hemi
 	}
+	if f.Sep != "" {
+		buf.WriteString(sign + f.Deg)
+		for _, v := range []string{f.Min, f.Sec} {
+			if v != "" {
+				buf.WriteString(f.Sep + v)
+			}
+		}
+		if sign == "" && f.Hemi != "" {
+			fmt.Fprintf(&buf, " %v", f.Hemi)
+		}
+		return buf.String()
+	}
+	if f.Unit != DegUnit {
+		fmt.Fprintf(&buf, "%v%v %v", sign, f.Deg, f.DegSym)
+		if sign == "" && f.Hemi != "" {
+			fmt.Fprintf(&buf, " %v", f.Hemi)
+		}
+		return buf.String()
+	}
 	degSym := f.DegSym
 	if degSym == "" {
 		degSym = "°"
@@ -118,74 +199,376 @@ func (f Fields) String() string {
 	return buf.String()
 }
 
+// Angle is an exact number of degrees. The zero value is 0°. Equal angles
+// compare equal with == and may be used as map keys.
 type Angle struct {
-	deg float64
-	min float64
-	sec float64
+	// deg is the value in lowest terms as written by big.Rat.RatString, or
+	// empty for zero
+	deg string
+}
+
+func newAngle(r *big.Rat) Angle {
+	if r.Sign() == 0 {
+		return Angle{}
+	}
+	return Angle{deg: r.RatString()}
 }
 
+var (
+	ratZero = new(big.Rat)
+	rat15   = big.NewRat(15, 1)
+	rat60   = big.NewRat(60, 1)
+	rat90   = big.NewRat(90, 1)
+	rat180  = big.NewRat(180, 1)
+	rat360  = big.NewRat(360, 1)
+	rat3600 = big.NewRat(3600, 1)
+)
+
+// ErrNotFinite is returned when an angle would be NaN or infinite
+var ErrNotFinite = errors.New("value is not finite")
+
+// scalar returns the exact value of v. An angle cannot hold NaN or an
+// infinity so an error is returned if v is not finite.
+func scalar(v float64) (*big.Rat, error) {
+	r := new(big.Rat)
+	if r.SetFloat64(v) == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotFinite, v)
+	}
+	return r, nil
+}
+
+// mustScalar is scalar for values that are known to be finite
+func mustScalar(v float64) *big.Rat {
+	r, err := scalar(v)
+	if err != nil {
+		panic("dms: " + err.Error())
+	}
+	return r
+}
+
+func ratFloat(v float64) *big.Rat {
+	return mustScalar(math.Abs(v))
+}
+
+// NewAngle panics if any of the values are not finite. Use NewAngleUnit for
+// values that might not be.
 func NewAngle(deg float64, min float64, sec float64) Angle {
-	sign := 1.
-	if deg < 0 {
-		sign = -1.
+	return newAngleRat(math.Signbit(deg), ratFloat(deg), ratFloat(min), ratFloat(sec))
+}
+
+func NewAngleRat(deg *big.Rat, min *big.Rat, sec *big.Rat) Angle {
+	var d, m, s big.Rat
+	return newAngleRat(deg.Sign() < 0, d.Abs(deg), m.Abs(min), s.Abs(sec))
+}
+
+func newAngleRat(neg bool, deg *big.Rat, min *big.Rat, sec *big.Rat) Angle {
+	r := new(big.Rat).Set(deg)
+	r.Add(r, new(big.Rat).Quo(min, rat60))
+	r.Add(r, new(big.Rat).Quo(sec, rat3600))
+	if neg {
+		r.Neg(r)
 	}
+	return newAngle(r)
+}
 
-	deg, min, sec = math.Abs(deg), math.Abs(min), math.Abs(sec)
+func NewAngleHMS(hour float64, min float64, sec float64) Angle {
+	a := NewAngle(hour, min, sec)
+	return newAngle(new(big.Rat).Mul(a.rat(), rat15))
+}
 
-	// Normalize floats
-	if ideg, fdeg := math.Modf(deg); fdeg != 0 {
-		deg = ideg
-		min += math.Abs(fdeg) * 60
+// unitScale returns the number of units in one degree. Radians are
+// irrational so the scale is the nearest float64.
+func unitScale(u Unit) *big.Rat {
+	switch u {
+	case MinUnit:
+		return rat60
+	case SecUnit:
+		return rat3600
+	case GradUnit:
+		return big.NewRat(10, 9)
+	case MilUnit:
+		return big.NewRat(160, 9)
+	case TurnUnit:
+		return big.NewRat(1, 360)
+	case RadUnit:
+		return mustScalar(pi180)
 	}
-	if imin, fmin := math.Modf(min); fmin != 0 {
-		min = imin
-		sec += math.Abs(fmin) * 60
+	return big.NewRat(1, 1)
+}
+
+// NewAngleUnit returns v in the given unit as an angle. An error wrapping
+// ErrNotFinite is returned if v is NaN or infinite.
+func NewAngleUnit(v float64, u Unit) (Angle, error) {
+	r, err := scalar(v)
+	if err != nil {
+		return Angle{}, err
 	}
+	return newAngle(r.Quo(r, unitScale(u))), nil
+}
+
+func newAngleUnit(v float64, u Unit) Angle {
+	return newAngle(new(big.Rat).Quo(mustScalar(v), unitScale(u)))
+}
+
+func NewAngleGradians(v float64) Angle {
+	return newAngleUnit(v, GradUnit)
+}
 
-	return Angle{}.Add(Angle{
-		deg: deg * sign,
-		min: min * sign,
-		sec: sec * sign,
-	})
+func NewAngleMils(v float64) Angle {
+	return newAngleUnit(v, MilUnit)
+}
+
+func NewAngleTurns(v float64) Angle {
+	return newAngleUnit(v, TurnUnit)
+}
+
+func NewAngleRadians(v float64) Angle {
+	return newAngleUnit(v, RadUnit)
+}
+
+// rat returns a new copy of the value that may be changed by the caller
+func (a Angle) rat() *big.Rat {
+	r := new(big.Rat)
+	if a.deg != "" {
+		r.SetString(a.deg)
+	}
+	return r
+}
+
+func (a Angle) Rat() *big.Rat {
+	return a.rat()
 }
 
 func (a Angle) String() string {
-	return fmt.Sprintf("(%v,%v,%v)", a.deg, a.min, a.sec)
+	deg, min, sec := a.DMS()
+	return fmt.Sprintf("(%v,%v,%v)", deg, min, sec)
 }
 
 func (a Angle) Add(a2 Angle) Angle {
-	var carry float64
-
-	sec := a.sec + a2.sec
-	carry, a.sec = float64(int(sec/60)), math.Mod(sec, 60)
-	min := a.min + a2.min + carry
-	carry, a.min = float64(int(min/60)), math.Mod(min, 60)
-	a.deg = a.deg + a2.deg + carry
-	return a
+	return newAngle(new(big.Rat).Add(a.rat(), a2.rat()))
 }
 
 func (a Angle) Sub(a2 Angle) Angle {
-	a2neg := Angle{deg: -a2.deg, min: -a2.min, sec: -a2.sec}
-	return a.Add(a2neg)
+	return newAngle(new(big.Rat).Sub(a.rat(), a2.rat()))
+}
+
+func (a Angle) Sign() int {
+	return a.rat().Sign()
+}
+
+func (a Angle) Neg() Angle {
+	return newAngle(new(big.Rat).Neg(a.rat()))
+}
+
+func (a Angle) Abs() Angle {
+	return newAngle(new(big.Rat).Abs(a.rat()))
+}
+
+// Mul returns an error if v is not finite
+func (a Angle) Mul(v float64) (Angle, error) {
+	r, err := scalar(v)
+	if err != nil {
+		return Angle{}, err
+	}
+	return newAngle(r.Mul(a.rat(), r)), nil
+}
+
+// Div returns an error if v is zero or not finite
+func (a Angle) Div(v float64) (Angle, error) {
+	r, err := scalar(v)
+	if err != nil {
+		return Angle{}, err
+	}
+	if r.Sign() == 0 {
+		return Angle{}, errors.New("division by zero")
+	}
+	return newAngle(r.Quo(a.rat(), r)), nil
+}
+
+// Compare returns -1, 0, or +1 if the angle is less than, equal to, or
+// greater than a2
+func (a Angle) Compare(a2 Angle) int {
+	return a.rat().Cmp(a2.rat())
+}
+
+// Equal returns true if the angles differ by no more than tol. Angles are
+// not wrapped before comparing; use Diff for headings.
+func (a Angle) Equal(a2 Angle, tol Angle) bool {
+	return a.Sub(a2).Abs().Compare(tol.Abs()) <= 0
+}
+
+// modRat returns r modulo m with the result having the same sign as m
+func modRat(r *big.Rat, m *big.Rat) *big.Rat {
+	q := new(big.Rat).Quo(r, m)
+	// Euclidean division floors since the denominator is positive
+	n := new(big.Int).Div(q.Num(), q.Denom())
+	return q.Sub(r, new(big.Rat).Mul(new(big.Rat).SetInt(n), m))
+}
+
+// Wrap360 returns the angle in the range [0, 360)
+func (a Angle) Wrap360() Angle {
+	return newAngle(modRat(a.rat(), rat360))
+}
+
+// Wrap180 returns the angle in the range [-180, 180)
+func (a Angle) Wrap180() Angle {
+	r := modRat(new(big.Rat).Add(a.rat(), rat180), rat360)
+	return newAngle(r.Sub(r, rat180))
+}
+
+// FoldLat brings a latitude that has gone past a pole back into the range
+// [-90, 90]. Crossing a pole moves to the other side of the globe so the
+// longitude is flipped by 180 degrees. The longitude is returned in the
+// range [-180, 180).
+func FoldLat(lat Angle, lon Angle) (Angle, Angle) {
+	r := lat.Wrap180().rat()
+	flip := true
+	switch {
+	case r.Cmp(rat90) > 0:
+		r = new(big.Rat).Sub(rat180, r)
+	case r.Cmp(new(big.Rat).Neg(rat90)) < 0:
+		r = new(big.Rat).Sub(new(big.Rat).Neg(rat180), r)
+	default:
+		flip = false
+	}
+	if flip {
+		lon = lon.Add(newAngle(rat180))
+	}
+	return newAngle(r), lon.Wrap180()
+}
+
+// Diff returns the signed shortest turn from one heading to another in the
+// range [-180, 180). Positive values are clockwise.
+func Diff(from Angle, to Angle) Angle {
+	return to.Sub(from).Wrap180()
 }
 
 func (a Angle) Degrees() float64 {
-	return a.deg + (a.min / 60) + (a.sec / 3600)
+	v, _ := a.rat().Float64()
+	return v
 }
 
 func (a Angle) Minutes() float64 {
-	return (a.deg * 60) + a.min + (a.sec / 60)
+	v, _ := new(big.Rat).Mul(a.rat(), rat60).Float64()
+	return v
 }
 
 func (a Angle) Seconds() float64 {
-	return (a.deg * 3600) + (a.min * 60) + a.sec
+	v, _ := new(big.Rat).Mul(a.rat(), rat3600).Float64()
+	return v
+}
+
+func (a Angle) Hours() float64 {
+	v, _ := new(big.Rat).Quo(a.rat(), rat15).Float64()
+	return v
 }
 
 func (a Angle) Radians() float64 {
 	return a.Degrees() * pi180
 }
 
+func (a Angle) Gradians() float64 {
+	v, _ := new(big.Rat).Mul(a.rat(), unitScale(GradUnit)).Float64()
+	return v
+}
+
+func (a Angle) Mils() float64 {
+	v, _ := new(big.Rat).Mul(a.rat(), unitScale(MilUnit)).Float64()
+	return v
+}
+
+func (a Angle) Turns() float64 {
+	v, _ := new(big.Rat).Quo(a.rat(), rat360).Float64()
+	return v
+}
+
+// sincos reduces the angle exactly before converting to radians so that
+// large angles keep their precision and multiples of 90 degrees are exact
+func (a Angle) sincos() (sin float64, cos float64) {
+	r := a.Wrap180().rat()
+	if r.IsInt() {
+		switch r.Num().Int64() {
+		case 0:
+			return 0, 1
+		case 90:
+			return 1, 0
+		case -90:
+			return -1, 0
+		case -180:
+			return 0, -1
+		}
+	}
+	deg, _ := r.Float64()
+	return math.Sincos(deg * pi180)
+}
+
+func (a Angle) Sin() float64 {
+	sin, _ := a.sincos()
+	return sin
+}
+
+func (a Angle) Cos() float64 {
+	_, cos := a.sincos()
+	return cos
+}
+
+func (a Angle) Tan() float64 {
+	sin, cos := a.sincos()
+	return sin / cos
+}
+
+func radians(v float64) (Angle, error) {
+	return NewAngleUnit(v, RadUnit)
+}
+
+// Asin returns an error if v is outside of [-1, 1]
+func Asin(v float64) (Angle, error) {
+	if err := checkUnit("asin", v); err != nil {
+		return Angle{}, err
+	}
+	return radians(math.Asin(v))
+}
+
+// Acos returns an error if v is outside of [-1, 1]
+func Acos(v float64) (Angle, error) {
+	if err := checkUnit("acos", v); err != nil {
+		return Angle{}, err
+	}
+	return radians(math.Acos(v))
+}
+
+func checkUnit(name string, v float64) error {
+	if !(v >= -1 && v <= 1) {
+		return fmt.Errorf("%v argument out of range: %v", name, v)
+	}
+	return nil
+}
+
+// Atan2 returns an error if y or x is NaN
+func Atan2(y float64, x float64) (Angle, error) {
+	return radians(math.Atan2(y, x))
+}
+
+// split returns the sign and the whole degrees, whole minutes, and seconds
+// of the angle without rounding.
+func (a Angle) split() (neg bool, deg *big.Int, min *big.Int, sec *big.Rat) {
+	r := new(big.Rat).Abs(a.rat())
+	deg, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	r.SetFrac(rem, new(big.Int).Set(r.Denom()))
+	r.Mul(r, rat60)
+	min, rem = new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	r.SetFrac(rem, new(big.Int).Set(r.Denom()))
+	sec = r.Mul(r, rat60)
+	return a.Sign() < 0, deg, min, sec
+}
+
 func (a Angle) DMS() (deg, min, sec float64) {
-	deg, min, sec = a.deg, math.Abs(a.min), math.Abs(a.sec)
+	neg, d, m, s := a.split()
+	deg, _ = new(big.Float).SetInt(d).Float64()
+	if neg {
+		deg = math.Copysign(deg, -1)
+	}
+	min, _ = new(big.Float).SetInt(m).Float64()
+	sec, _ = s.Float64()
 	return
 }
@@ -3,6 +3,7 @@ package dms
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 )
 
@@ -150,3 +151,416 @@ func TestAngleRadians(t *testing.T) {
 		t.Errorf("\n have: %v \n want: %v", have, want)
 	}
 }
+
+func TestAngleUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		angle Angle
+		deg   string
+		value func(Angle) float64
+		want  string
+	}{
+		{"gradians", NewAngleGradians(100), "90.000000", Angle.Gradians, "100.000000"},
+		{"gradians", NewAngle(-45, 0, 0), "-45.000000", Angle.Gradians, "-50.000000"},
+		{"mils", NewAngleMils(1600), "90.000000", Angle.Mils, "1600.000000"},
+		{"mils", NewAngle(1, 0, 0), "1.000000", Angle.Mils, "17.777778"},
+		{"turns", NewAngleTurns(0.25), "90.000000", Angle.Turns, "0.250000"},
+		{"turns", NewAngle(-720, 0, 0), "-720.000000", Angle.Turns, "-2.000000"},
+		{"radians", NewAngleRadians(math.Pi), "180.000000", Angle.Radians, "3.141593"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deg := fmt.Sprintf("%.6f", test.angle.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+			have := fmt.Sprintf("%.6f", test.value(test.angle))
+			if have != test.want {
+				t.Errorf("\n have: %v \n want: %v", have, test.want)
+			}
+		})
+	}
+}
+
+func TestAngleHours(t *testing.T) {
+	have := fmt.Sprintf("%.6f", NewAngleHMS(14, 29, 42.9).Hours())
+	want := "14.495250"
+	if have != want {
+		t.Errorf("\n have: %v \n want: %v", have, want)
+	}
+}
+
+func TestAngleExact(t *testing.T) {
+	p := NewDefaultParser()
+	tenth, err := p.Parse(`0°0′0.1″`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tenth.Seconds(); s != 0.1 {
+		t.Errorf("\n have: %v \n want: 0.1", s)
+	}
+
+	var sum Angle
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(tenth)
+	}
+	one, err := p.Parse(`0°0′1″`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Rat().Cmp(one.Rat()) != 0 {
+		t.Errorf("\n have: %v \n want: %v", sum.Rat(), one.Rat())
+	}
+	if diff := sum.Sub(one); diff.Sign() != 0 {
+		t.Errorf("\n have: %v \n want: 0", diff.Rat())
+	}
+
+	a, err := p.Parse(`12°34′56.789″`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deg, min, sec := a.DMS()
+	if deg != 12 || min != 34 || sec != 56.789 {
+		t.Errorf("\n have: %v %v %v \n want: 12 34 56.789", deg, min, sec)
+	}
+}
+
+func TestAngleRat(t *testing.T) {
+	tests := []struct {
+		angle Angle
+		rat   string
+	}{
+		{NewAngle(1, 30, 0), "3/2"},
+		{NewAngle(-1, 30, 0), "-3/2"},
+		{NewAngleRat(big.NewRat(-1, 1), big.NewRat(30, 1), big.NewRat(36, 1)), "-151/100"},
+		{NewAngleHMS(1, 0, 0), "15/1"},
+		{Angle{}, "0/1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rat, func(t *testing.T) {
+			rat := test.angle.Rat().String()
+			if rat != test.rat {
+				t.Errorf("\n have: %v \n want: %v", rat, test.rat)
+			}
+		})
+	}
+}
+
+func TestAngleWrap(t *testing.T) {
+	third := NewAngleRat(big.NewRat(1, 3), new(big.Rat), new(big.Rat))
+	tests := []struct {
+		angle  Angle
+		wrap   string
+		wrap18 string
+	}{
+		{NewAngle(0, 0, 0), "0/1", "0/1"},
+		{NewAngle(359, 59, 59), "1295999/3600", "-1/3600"},
+		{NewAngle(360, 0, 0), "0/1", "0/1"},
+		{NewAngle(370, 0, 0.5), "72001/7200", "72001/7200"},
+		{NewAngle(-10, 0, 0), "350/1", "-10/1"},
+		{NewAngle(-180, 0, 0), "180/1", "-180/1"},
+		{NewAngle(180, 0, 0), "180/1", "-180/1"},
+		{NewAngle(-720, 0, 0), "0/1", "0/1"},
+		{third.Add(NewAngle(1080, 0, 0)), "1/3", "1/3"},
+		{third.Sub(NewAngle(1080, 0, 0)), "1/3", "1/3"},
+		{third.Add(NewAngle(200, 0, 0)), "601/3", "-479/3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.angle.Rat().String(), func(t *testing.T) {
+			wrap := test.angle.Wrap360().Rat().String()
+			wrap18 := test.angle.Wrap180().Rat().String()
+			if wrap != test.wrap || wrap18 != test.wrap18 {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", wrap, wrap18, test.wrap, test.wrap18)
+			}
+		})
+	}
+}
+
+func TestFoldLat(t *testing.T) {
+	tests := []struct {
+		lat   Angle
+		lon   Angle
+		coord string
+	}{
+		{NewAngle(45, 0, 0), NewAngle(10, 0, 0), "45° 0′ 0″ N, 10° 0′ 0″ E"},
+		{NewAngle(90, 0, 0), NewAngle(10, 0, 0), "90° 0′ 0″ N, 10° 0′ 0″ E"},
+		{NewAngle(91, 0, 0.5), NewAngle(10, 0, 0), "88° 59′ 59.5″ N, 170° 0′ 0″ W"},
+		{NewAngle(-95, 0, 0), NewAngle(-100, 0, 0), "85° 0′ 0″ S, 80° 0′ 0″ E"},
+		{NewAngle(180, 0, 0), NewAngle(0, 0, 0), "0° 0′ 0″ N, 180° 0′ 0″ W"},
+		{NewAngle(270, 0, 0), NewAngle(0, 0, 0), "90° 0′ 0″ S, 0° 0′ 0″ E"},
+		{NewAngle(450, 0, 0), NewAngle(0, 0, 0), "90° 0′ 0″ N, 0° 0′ 0″ E"},
+		{NewAngle(-269, 0, 0), NewAngle(190, 0, 0), "89° 0′ 0″ N, 10° 0′ 0″ E"},
+	}
+
+	f := NewFormatter(SecUnit, -1)
+	for _, test := range tests {
+		t.Run(test.coord, func(t *testing.T) {
+			c := NewCoordinate(test.lat, test.lon).Normalize()
+			coord := f.FormatLat(c.Lat) + ", " + f.FormatLon(c.Lon)
+			if coord != test.coord {
+				t.Errorf("\n have: %v \n want: %v", coord, test.coord)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		from float64
+		to   float64
+		diff float64
+	}{
+		{10, 20, 10},
+		{20, 10, -10},
+		{350, 10, 20},
+		{10, 350, -20},
+		{0, 180, -180},
+		{180, 0, -180},
+		{-170, 170, -20},
+		{720, 45, 45},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.to), func(t *testing.T) {
+			diff := Diff(NewAngle(test.from, 0, 0), NewAngle(test.to, 0, 0))
+			if diff.Degrees() != test.diff {
+				t.Errorf("\n have: %v \n want: %v", diff.Degrees(), test.diff)
+			}
+		})
+	}
+}
+
+func TestAngleScalar(t *testing.T) {
+	a := NewAngle(10, 30, 0)
+	tests := []struct {
+		name  string
+		angle Angle
+		rat   string
+	}{
+		{"neg", a.Neg(), "-21/2"},
+		{"neg neg", a.Neg().Neg(), "21/2"},
+		{"abs", a.Neg().Abs(), "21/2"},
+		{"abs zero", Angle{}.Abs(), "0/1"},
+		{"mul", mustAngle(a.Mul(2)), "21/1"},
+		{"mul neg", mustAngle(a.Mul(-0.5)), "-21/4"},
+		{"div", mustAngle(a.Div(3)), "7/2"},
+		{"div neg", mustAngle(a.Div(-7)), "-3/2"},
+		{"div exact", mustAngle(mustAngle(NewAngle(1, 0, 0).Div(3)).Mul(3)), "1/1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rat := test.angle.Rat().String()
+			if rat != test.rat {
+				t.Errorf("\n have: %v \n want: %v", rat, test.rat)
+			}
+		})
+	}
+}
+
+func mustAngle(a Angle, err error) Angle {
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestAngleNotFinite(t *testing.T) {
+	a := NewAngle(10, 30, 0)
+	tests := []struct {
+		name string
+		fn   func() (Angle, error)
+		want string
+	}{
+		{"mul nan", func() (Angle, error) { return a.Mul(math.NaN()) }, "value is not finite: NaN"},
+		{"mul inf", func() (Angle, error) { return a.Mul(math.Inf(1)) }, "value is not finite: +Inf"},
+		{"div nan", func() (Angle, error) { return a.Div(math.NaN()) }, "value is not finite: NaN"},
+		{"div inf", func() (Angle, error) { return a.Div(math.Inf(-1)) }, "value is not finite: -Inf"},
+		{"div zero", func() (Angle, error) { return a.Div(0) }, "division by zero"},
+		{"asin 2", func() (Angle, error) { return Asin(2) }, "asin argument out of range: 2"},
+		{"asin nan", func() (Angle, error) { return Asin(math.NaN()) }, "asin argument out of range: NaN"},
+		{"acos -1.5", func() (Angle, error) { return Acos(-1.5) }, "acos argument out of range: -1.5"},
+		{"atan2 nan", func() (Angle, error) { return Atan2(math.NaN(), 1) }, "value is not finite: NaN"},
+		{"unit nan", func() (Angle, error) { return NewAngleUnit(math.NaN(), DegUnit) }, "value is not finite: NaN"},
+		{"unit mils inf", func() (Angle, error) { return NewAngleUnit(math.Inf(1), MilUnit) }, "value is not finite: +Inf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.fn()
+			have := fmt.Sprint(err)
+			if have != test.want {
+				t.Errorf("\n have: %v \n want: %v", have, test.want)
+			}
+		})
+	}
+}
+
+func TestNewAngleNotFinite(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"new nan", func() { NewAngle(math.NaN(), 0, 0) }, "dms: value is not finite: NaN"},
+		{"new inf", func() { NewAngle(1, 0, math.Inf(-1)) }, "dms: value is not finite: +Inf"},
+		{"new mils inf", func() { NewAngleMils(math.Inf(1)) }, "dms: value is not finite: +Inf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				have := fmt.Sprint(recover())
+				if have != test.want {
+					t.Errorf("\n have: %v \n want: %v", have, test.want)
+				}
+			}()
+			test.fn()
+		})
+	}
+}
+
+func TestAngleCompare(t *testing.T) {
+	tests := []struct {
+		a   Angle
+		b   Angle
+		cmp int
+	}{
+		{NewAngle(1, 0, 0), NewAngle(2, 0, 0), -1},
+		{NewAngle(2, 0, 0), NewAngle(1, 59, 60), 0},
+		{NewAngle(-1, 0, 0), NewAngle(-2, 0, 0), 1},
+		{Angle{}, NewAngle(0, 0, 0), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.a, test.b), func(t *testing.T) {
+			cmp := test.a.Compare(test.b)
+			if cmp != test.cmp {
+				t.Errorf("\n have: %v \n want: %v", cmp, test.cmp)
+			}
+		})
+	}
+}
+
+func TestAngleEqual(t *testing.T) {
+	tests := []struct {
+		a     Angle
+		b     Angle
+		equal bool
+	}{
+		{NewAngle(1, 30, 0), NewAngle(1.5, 0, 0), true},
+		{Angle{}, NewAngle(0, 0, 0), true},
+		{NewAngle(-0.0, 0, 0), NewAngle(0, 0, 0), true},
+		{NewAngle(1, 0, 0), NewAngle(1, 0, 0.001), false},
+		{mustAngle(mustAngle(NewAngle(1, 0, 0).Div(3)).Mul(3)), NewAngle(1, 0, 0), true},
+		{NewAngle(1, 0, 0).Sub(NewAngle(1, 0, 0)), Angle{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.a, test.b), func(t *testing.T) {
+			equal := test.a == test.b
+			if equal != test.equal {
+				t.Errorf("\n have: %v \n want: %v", equal, test.equal)
+			}
+		})
+	}
+}
+
+func TestAngleEqualTolerance(t *testing.T) {
+	tests := []struct {
+		a     Angle
+		b     Angle
+		tol   Angle
+		equal bool
+	}{
+		{NewAngle(1, 0, 0), NewAngle(1, 0, 0), Angle{}, true},
+		{NewAngle(1, 0, 0), NewAngle(1, 0, 1), Angle{}, false},
+		{NewAngle(1, 0, 0), NewAngle(1, 0, 1), NewAngle(0, 0, 1), true},
+		{NewAngle(1, 0, 1), NewAngle(1, 0, 0), NewAngle(0, 0, 1), true},
+		{NewAngle(1, 0, 1), NewAngle(1, 0, 0), NewAngle(0, 0, -1), true},
+		{NewAngle(1, 0, 1.1), NewAngle(1, 0, 0), NewAngle(0, 0, 1), false},
+		{NewAngle(359, 59, 59), NewAngle(0, 0, 0), NewAngle(0, 0, 1), false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.a, test.b, test.tol), func(t *testing.T) {
+			equal := test.a.Equal(test.b, test.tol)
+			if equal != test.equal {
+				t.Errorf("\n have: %v \n want: %v", equal, test.equal)
+			}
+		})
+	}
+}
+
+func TestAngleMapKey(t *testing.T) {
+	m := map[Angle]string{NewAngle(45, 0, 0): "NE"}
+	if v := m[NewAngle(0, 2700, 0)]; v != "NE" {
+		t.Errorf("\n have: %v \n want: %v", v, "NE")
+	}
+}
+
+func TestAngleTrig(t *testing.T) {
+	tests := []struct {
+		deg float64
+		sin float64
+		cos float64
+		tan float64
+	}{
+		{0, 0, 1, 0},
+		{30, 0.5, math.Sqrt(3) / 2, 1 / math.Sqrt(3)},
+		{45, math.Sqrt2 / 2, math.Sqrt2 / 2, 1},
+		{90, 1, 0, math.Inf(1)},
+		{180, 0, -1, 0},
+		{270, -1, 0, math.Inf(-1)},
+		{-90, -1, 0, math.Inf(-1)},
+		{3600 + 30, 0.5, math.Sqrt(3) / 2, 1 / math.Sqrt(3)},
+	}
+
+	near := func(a, b float64) bool {
+		if math.IsInf(a, 0) || math.IsInf(b, 0) {
+			return a == b
+		}
+		return math.Abs(a-b) < 1e-15
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.deg), func(t *testing.T) {
+			a := NewAngle(test.deg, 0, 0)
+			sin, cos, tan := a.Sin(), a.Cos(), a.Tan()
+			if !near(sin, test.sin) || !near(cos, test.cos) || !near(tan, test.tan) {
+				t.Errorf("\n have: %v %v %v \n want: %v %v %v", sin, cos, tan, test.sin, test.cos, test.tan)
+			}
+		})
+	}
+}
+
+func TestAngleInverseTrig(t *testing.T) {
+	tests := []struct {
+		name  string
+		angle Angle
+		deg   string
+	}{
+		{"asin 1", mustAngle(Asin(1)), "90.000000"},
+		{"asin 0.5", mustAngle(Asin(0.5)), "30.000000"},
+		{"asin -0.5", mustAngle(Asin(-0.5)), "-30.000000"},
+		{"acos 0", mustAngle(Acos(0)), "90.000000"},
+		{"acos -1", mustAngle(Acos(-1)), "180.000000"},
+		{"acos 0.5", mustAngle(Acos(0.5)), "60.000000"},
+		{"atan2 1 1", mustAngle(Atan2(1, 1)), "45.000000"},
+		{"atan2 1 -1", mustAngle(Atan2(1, -1)), "135.000000"},
+		{"atan2 -1 -1", mustAngle(Atan2(-1, -1)), "-135.000000"},
+		{"atan2 0 -1", mustAngle(Atan2(0, -1)), "180.000000"},
+		{"asin sin", mustAngle(Asin(NewAngle(12, 34, 56).Sin())), "12.582222"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deg := fmt.Sprintf("%.6f", test.angle.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
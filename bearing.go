@@ -0,0 +1,108 @@
+package dms
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/blackchip-org/scan"
+)
+
+// Bearing is a quadrant bearing such as N 45° 30′ E. The angle is measured
+// from north or south towards east or west and is between 0 and 90 degrees.
+type Bearing struct {
+	From  string
+	Angle Angle
+	To    string
+}
+
+// NewBearing returns the quadrant bearing for an azimuth measured clockwise
+// from north in the range [0, 360).
+func NewBearing(azimuth Angle) (Bearing, error) {
+	az := azimuth.rat()
+	if az.Sign() < 0 || az.Cmp(rat360) >= 0 {
+		return Bearing{}, fmt.Errorf("azimuth out of range: %v", azimuth.Degrees())
+	}
+	sub := func(x, y *big.Rat) Angle {
+		return newAngle(new(big.Rat).Sub(x, y))
+	}
+	switch {
+	case az.Cmp(rat90) <= 0:
+		return Bearing{From: NorthType, Angle: azimuth, To: EastType}, nil
+	case az.Cmp(rat180) <= 0:
+		return Bearing{From: SouthType, Angle: sub(rat180, az), To: EastType}, nil
+	case az.Cmp(big.NewRat(270, 1)) < 0:
+		return Bearing{From: SouthType, Angle: sub(az, rat180), To: WestType}, nil
+	}
+	return Bearing{From: NorthType, Angle: sub(rat360, az), To: WestType}, nil
+}
+
+// Azimuth returns the bearing as an angle measured clockwise from north in
+// the range [0, 360).
+func (b Bearing) Azimuth() Angle {
+	a := b.Angle.rat()
+	r := new(big.Rat)
+	switch {
+	case b.From == NorthType && b.To == EastType:
+		r.Set(a)
+	case b.From == SouthType && b.To == EastType:
+		r.Sub(rat180, a)
+	case b.From == SouthType && b.To == WestType:
+		r.Add(rat180, a)
+	default:
+		r.Sub(rat360, a)
+		if r.Cmp(rat360) == 0 {
+			r.SetInt64(0)
+		}
+	}
+	return newAngle(r)
+}
+
+func (b Bearing) String() string {
+	return fmt.Sprintf("%v %v %v", b.From, b.Angle, b.To)
+}
+
+func (p *Parser) ParseBearing(v string) (Bearing, error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+
+	tok := r.This
+	if tok.Type != NorthType && tok.Type != SouthType {
+		return Bearing{}, NewError(tok, `expected "N" or "S", got %v`, scan.Quote(tok.Lit))
+	}
+	from := tok.Type
+	if tok := r.Scan(); tok.Type == "+" || tok.Type == "-" {
+		return Bearing{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+	}
+
+	f, toks, err := parseFields(r, p.ctx)
+	if err != nil {
+		return Bearing{}, err
+	}
+	// Bare numbers end before the hemisphere
+	if tok := r.This; f.Hemi == "" && (tok.Type == EastType || tok.Type == WestType) {
+		f.Hemi = tok.Type
+		toks.Hemi = tok
+		r.Scan()
+	}
+	if hemiAxis(f.Hemi) != LonAxis {
+		tok := toks.Hemi
+		if f.Hemi == "" {
+			tok = r.This
+		}
+		return Bearing{}, NewError(tok, `expected "E" or "W", got %v`, scan.Quote(tok.Lit))
+	}
+	if tok := r.This; !tok.IsEndOfText() {
+		return Bearing{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+	}
+
+	to := f.Hemi
+	f.Hemi = ""
+	a, err := f.angle()
+	if err != nil {
+		return Bearing{}, err
+	}
+	if a.rat().Cmp(rat90) > 0 {
+		return Bearing{}, NewError(toks.Deg, "bearing out of range: %v", scan.Quote(f.String()))
+	}
+	return Bearing{From: from, Angle: a, To: to}, nil
+}
@@ -0,0 +1,103 @@
+package dms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseBearing(t *testing.T) {
+	tests := []struct {
+		input   string
+		bearing string
+		azimuth string
+		err     string
+	}{
+		{`N 45°30′15″ E`, "N 45° 30′ 15.0″ E", "45.504167", ""},
+		{`S 12°W`, "S 12° 0′ 0.0″ W", "192.000000", ""},
+		{`S 12° 30′ E`, "S 12° 30′ 0.0″ E", "167.500000", ""},
+		{`N 30 W`, "N 30° 0′ 0.0″ W", "330.000000", ""},
+		{`N 0.5 W`, "N 0° 30′ 0.0″ W", "359.500000", ""},
+		{`N0°E`, "N 0° 0′ 0.0″ E", "0.000000", ""},
+		{`N 0° W`, "N 0° 0′ 0.0″ W", "0.000000", ""},
+		{`S 90° E`, "S 90° 0′ 0.0″ E", "90.000000", ""},
+		{`S 0° W`, "S 0° 0′ 0.0″ W", "180.000000", ""},
+
+		{`45° E`, "", "", `1:1: expected "N" or "S", got "45"`},
+		{`E 45° N`, "", "", `1:1: expected "N" or "S", got "E"`},
+		{`N 45°`, "", "", `1:6: expected "E" or "W", got ""`},
+		{`N 45° S`, "", "", `1:7: expected "E" or "W", got "S"`},
+		{`N -45° E`, "", "", `1:3: unexpected "-"`},
+		{`N 90°0′1″ E`, "", "", `1:3: bearing out of range: "90° 0′ 1″"`},
+		{`N 135° E`, "", "", `1:3: bearing out of range: "135°"`},
+		{`N 3h E`, "", "", `1:6: hemisphere "E" not allowed with hours`},
+		{`N 3h`, "", "", `1:5: expected "E" or "W", got ""`},
+		{`N 45° E x`, "", "", `1:9: unexpected "x"`},
+	}
+
+	f := NewFormatter(SecUnit, 1)
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			b, err := p.ParseBearing(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			bearing := f.FormatBearing(b)
+			azimuth := fmt.Sprintf("%.6f", b.Azimuth().Degrees())
+			if bearing != test.bearing || azimuth != test.azimuth {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", bearing, azimuth, test.bearing, test.azimuth)
+			}
+		})
+	}
+}
+
+func TestNewBearing(t *testing.T) {
+	tests := []struct {
+		azimuth float64
+		bearing string
+		err     string
+	}{
+		{0, "N 0° 0′ E", ""},
+		{45.5, "N 45° 30′ E", ""},
+		{90, "N 90° 0′ E", ""},
+		{90.25, "S 89° 45′ E", ""},
+		{180, "S 0° 0′ E", ""},
+		{200, "S 20° 0′ W", ""},
+		{270, "N 90° 0′ W", ""},
+		{359.5, "N 0° 30′ W", ""},
+
+		{-1, "", "azimuth out of range: -1"},
+		{360, "", "azimuth out of range: 360"},
+	}
+
+	f := NewFormatter(MinUnit, 0)
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.azimuth), func(t *testing.T) {
+			b, err := NewBearing(NewAngle(test.azimuth, 0, 0))
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if b.Azimuth().rat().Cmp(NewAngle(test.azimuth, 0, 0).rat()) != 0 {
+				t.Errorf("azimuth %v does not round trip: %v", test.azimuth, b.Azimuth())
+			}
+			bearing := f.FormatBearing(b)
+			if bearing != test.bearing {
+				t.Errorf("\n have: %v \n want: %v", bearing, test.bearing)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blackchip-org/dms"
+)
+
+const (
+	exitOK = iota
+	exitParseError
+	exitUsage
+)
+
+type options struct {
+	to      string
+	places  int
+	sep     string
+	symbols string
+	symSet  bool
+	locale  string
+	input   string
+	lat     bool
+	lon     bool
+	coord   bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	var opts options
+	fs := flag.NewFlagSet("dms", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.to, "to", "sec", "last unit to show: deg, min, sec, gon, mil, turn, or rad")
+	fs.IntVar(&opts.places, "places", 2, "number of decimal places for the last unit, or -1 for all")
+	fs.StringVar(&opts.sep, "sep", " ", "separator between fields")
+	fs.StringVar(&opts.symbols, "symbols", "°,′,″", "comma separated degree, minute, and second symbols")
+	fs.StringVar(&opts.locale, "locale", "", "format numbers and hemispheres for a locale such as fr or de")
+	fs.StringVar(&opts.input, "input-locale", "", "parse numbers and hemispheres for a locale such as fr or de")
+	fs.BoolVar(&opts.lat, "lat", false, "parse and format as a latitude")
+	fs.BoolVar(&opts.lon, "lon", false, "parse and format as a longitude")
+	fs.BoolVar(&opts.coord, "coord", false, "parse and format as a latitude and longitude pair")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: dms [options] [angle...]\n\n")
+		fmt.Fprintf(stderr, "Angles are read from standard input, one per line, when none are given.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	// Symbols from a locale are only replaced when given explicitly
+	fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == "symbols" {
+			opts.symSet = true
+		}
+	})
+
+	f, err := newFormatter(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "dms: %v\n", err)
+		return exitUsage
+	}
+	p, err := newParser(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "dms: %v\n", err)
+		return exitUsage
+	}
+	if btoi(opts.lat)+btoi(opts.lon)+btoi(opts.coord) > 1 {
+		fmt.Fprintf(stderr, "dms: only one of -lat, -lon, or -coord may be used\n")
+		return exitUsage
+	}
+
+	status := exitOK
+	convert := func(source string, line int, v string) {
+		result, err := format(p, f, opts, v)
+		if err != nil {
+			var perr *dms.Error
+			if errors.As(err, &perr) {
+				perr.Pos.Line = line
+				fmt.Fprintf(stderr, "%v:%v\n", source, err)
+			} else {
+				fmt.Fprintf(stderr, "%v: %v\n", source, err)
+			}
+			status = exitParseError
+			return
+		}
+		fmt.Fprintln(stdout, result)
+	}
+
+	if fs.NArg() > 0 {
+		for i, arg := range fs.Args() {
+			convert("arg", i+1, arg)
+		}
+		return status
+	}
+
+	line := 0
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line++
+		// Parse the untrimmed line so that error columns match the input
+		v := scanner.Text()
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		convert("stdin", line, v)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "dms: %v\n", err)
+		return exitParseError
+	}
+	return status
+}
+
+func newFormatter(opts options) (dms.Formatter, error) {
+	var to dms.Unit
+	switch opts.to {
+	case "deg":
+		to = dms.DegUnit
+	case "min":
+		to = dms.MinUnit
+	case "sec":
+		to = dms.SecUnit
+	case "gon", "grad":
+		to = dms.GradUnit
+	case "mil":
+		to = dms.MilUnit
+	case "turn":
+		to = dms.TurnUnit
+	case "rad":
+		to = dms.RadUnit
+	default:
+		return dms.Formatter{}, fmt.Errorf("invalid unit: %v", opts.to)
+	}
+	f := dms.NewFormatter(to, opts.places)
+	if opts.locale != "" {
+		l, ok := dms.LookupLocale(opts.locale)
+		if !ok {
+			return dms.Formatter{}, fmt.Errorf("unknown locale: %v", opts.locale)
+		}
+		f = f.WithLocale(l)
+	}
+	f = f.WithSep(opts.sep)
+	if opts.symSet {
+		syms := strings.Split(opts.symbols, ",")
+		if len(syms) != 3 {
+			return dms.Formatter{}, fmt.Errorf("expected three symbols, got %v", opts.symbols)
+		}
+		f = f.WithSymbols(syms[0], syms[1], syms[2])
+	}
+	return f, nil
+}
+
+func newParser(opts options) (*dms.Parser, error) {
+	if opts.input == "" {
+		return dms.NewDefaultParser(), nil
+	}
+	l, ok := dms.LookupLocale(opts.input)
+	if !ok {
+		return nil, fmt.Errorf("unknown locale: %v", opts.input)
+	}
+	return dms.NewParser(dms.NewLocaleContext(l, "")), nil
+}
+
+func format(p *dms.Parser, f dms.Formatter, opts options, v string) (string, error) {
+	switch {
+	case opts.coord:
+		c, err := p.ParseCoordinate(v)
+		if err != nil {
+			return "", err
+		}
+		return f.FormatLat(c.Lat) + ", " + f.FormatLon(c.Lon), nil
+	case opts.lat:
+		a, err := p.ParseLat(v)
+		if err != nil {
+			return "", err
+		}
+		return f.FormatLat(a), nil
+	case opts.lon:
+		a, err := p.ParseLon(v)
+		if err != nil {
+			return "", err
+		}
+		return f.FormatLon(a), nil
+	}
+	a, err := p.Parse(v)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(a), nil
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
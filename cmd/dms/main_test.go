@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blackchip-org/dms"
+)
+
+func TestRun(t *testing.T) {
+	dms.RegisterLocale(dms.Locale{Name: "test-symbols", Decimal: ",", Deg: "d", Min: "m", Sec: "s"})
+	t.Cleanup(func() { dms.UnregisterLocale("test-symbols") })
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		stdout string
+		stderr string
+		status int
+	}{
+		{"default", []string{"1.051667"}, "", "1° 3′ 6.00″\n", "", exitOK},
+		{"deg", []string{"-to", "deg", "-places", "6", `1° 3′ 6″`}, "", "1.051667°\n", "", exitOK},
+		{"min", []string{"--to", "min", "--places", "3", "--lat", `1° 3′ 6″ S`}, "", "1° 3.100′ S\n", "", exitOK},
+		{"symbols", []string{"-symbols", "d,m,s", "-sep", "", "-places", "1", "-lon", "--", "-1.5"}, "", "1d30m0.0sW\n", "", exitOK},
+		{"coord", []string{"-coord", "-places", "0", "40.446, -79.982"}, "", "40° 26′ 46″ N, 79° 58′ 55″ W\n", "", exitOK},
+		{"mil", []string{"-to", "mil", "-places", "0", "100 gon"}, "", "1600 mil\n", "", exitOK},
+		{"locale", []string{"-locale", "fr", "-places", "1", "-lon", "3.5°W"}, "", "3° 30′ 0,0″ O\n", "", exitOK},
+		{"input locale", []string{"-input-locale", "fr", "-places", "1", "-lon", "3,5° Ouest"}, "", "3° 30′ 0.0″ W\n", "", exitOK},
+		{"both locales", []string{"-input-locale", "es", "-locale", "de", "-places", "0", "-coord", "40,5, 3,5° O"}, "", "40° 30′ 0″ N, 3° 30′ 0″ W\n", "", exitOK},
+		{"locale symbols", []string{"-locale", "test-symbols", "-places", "1", "1°30′"}, "", "1d 30m 0,0s\n", "", exitOK},
+		{"explicit symbols", []string{"-locale", "test-symbols", "-symbols", "°,′,″", "-places", "1", "1°30′"}, "", "1° 30′ 0,0″\n", "", exitOK},
+		{"stdin", nil, "1.5\n\n2.25\n", "1° 30′ 0.00″\n2° 15′ 0.00″\n", "", exitOK},
+
+		{"bad arg", []string{"1", "1°x"}, "", "1° 0′ 0.00″\n", "arg:2:3: unexpected \"x\"\n", exitParseError},
+		{"bad line", nil, "1\n2\n3°60′\n", "1° 0′ 0.00″\n2° 0′ 0.00″\n", "stdin:3:3: invalid minute \"60\"\n", exitParseError},
+		{"bad indented line", nil, "  1\n  3°60′ \n", "1° 0′ 0.00″\n", "stdin:2:5: invalid minute \"60\"\n", exitParseError},
+		{"bad lat", []string{"-lat", "95"}, "", "", "arg:1:1: latitude out of range: \"95°\"\n", exitParseError},
+		{"bad unit", []string{"-to", "hr", "1"}, "", "", "dms: invalid unit: hr\n", exitUsage},
+		{"bad locale", []string{"-locale", "xx", "1"}, "", "", "dms: unknown locale: xx\n", exitUsage},
+		{"bad input locale", []string{"-input-locale", "xx", "1"}, "", "", "dms: unknown locale: xx\n", exitUsage},
+		{"bad symbols", []string{"-symbols", "d,m", "1"}, "", "", "dms: expected three symbols, got d,m\n", exitUsage},
+		{"bad axis", []string{"-lat", "-lon", "1"}, "", "", "dms: only one of -lat, -lon, or -coord may be used\n", exitUsage},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+			if status != test.status {
+				t.Errorf("\n have status: %v \n want status: %v", status, test.status)
+			}
+			if stdout.String() != test.stdout {
+				t.Errorf("\n have stdout: %q \n want stdout: %q", stdout.String(), test.stdout)
+			}
+			if stderr.String() != test.stderr {
+				t.Errorf("\n have stderr: %q \n want stderr: %q", stderr.String(), test.stderr)
+			}
+		})
+	}
+}
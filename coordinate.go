@@ -0,0 +1,101 @@
+package dms
+
+import (
+	"fmt"
+
+	"github.com/blackchip-org/scan"
+)
+
+type Coordinate struct {
+	Lat Angle
+	Lon Angle
+}
+
+func NewCoordinate(lat Angle, lon Angle) Coordinate {
+	return Coordinate{Lat: lat, Lon: lon}
+}
+
+// Normalize folds a latitude past a pole back into range and wraps the
+// longitude into [-180, 180)
+func (c Coordinate) Normalize() Coordinate {
+	lat, lon := FoldLat(c.Lat, c.Lon)
+	return NewCoordinate(lat, lon)
+}
+
+func (c Coordinate) String() string {
+	return fmt.Sprintf("(%v,%v)", c.Lat, c.Lon)
+}
+
+func (p *Parser) ParseCoordinateFields(v string) (lat Fields, lon Fields, err error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+
+	lat, lon, _, _, err = parseCoordinateFields(r, p.ctx)
+	return
+}
+
+func (p *Parser) ParseCoordinate(v string) (Coordinate, error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+
+	latFields, lonFields, latToks, lonToks, err := parseCoordinateFields(r, p.ctx)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lat, err := latFields.axisAngle(latToks, LatAxis)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lon, err := lonFields.axisAngle(lonToks, LonAxis)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return NewCoordinate(lat, lon), nil
+}
+
+func parseCoordinateFields(r *scan.Runner, ctx *Context) (lat Fields, lon Fields, latToks fieldTokens, lonToks fieldTokens, err error) {
+	lat, latToks, err = parseFields(r, ctx)
+	if err != nil {
+		return
+	}
+	comma := r.This.Type == CommaType
+	if comma {
+		r.Scan()
+	}
+	// Without a comma or hemisphere there is no way to tell where the
+	// first set of whitespace separated fields ends
+	if lat.Sep == " " && !comma && hemiAxis(lat.Hemi) == NoAxis {
+		err = NewError(r.This, "ambiguous coordinate, expected comma or hemisphere")
+		return
+	}
+	lon, lonToks, err = parseFields(r, ctx)
+	if err != nil {
+		return
+	}
+	if tok := r.This; !tok.IsEndOfText() {
+		err = NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+		return
+	}
+
+	ax1, ax2 := fieldsAxis(lat), fieldsAxis(lon)
+	if ax1 != NoAxis && ax1 == ax2 {
+		tok := lonToks.Hemi
+		if lon.Hours {
+			tok = lonToks.Deg
+		}
+		err = NewError(tok, "expected %v, got another %v", otherAxis(ax1), ax1)
+		return
+	}
+	if ax1 == LonAxis || ax2 == LatAxis {
+		lat, lon = lon, lat
+		latToks, lonToks = lonToks, latToks
+	}
+	return
+}
+
+func fieldsAxis(f Fields) Axis {
+	if f.Hours {
+		return LonAxis
+	}
+	return hemiAxis(f.Hemi)
+}
@@ -0,0 +1,134 @@
+package dms
+
+import "testing"
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		lat   string
+		lon   string
+		err   string
+	}{
+		{`40°26′46″N 79°58′56″W`, "40° 26′ 46.0″ N", "79° 58′ 56.0″ W", ""},
+		{`40°26′46″N, 79°58′56″W`, "40° 26′ 46.0″ N", "79° 58′ 56.0″ W", ""},
+		{`79°58′56″W 40°26′46″N`, "40° 26′ 46.0″ N", "79° 58′ 56.0″ W", ""},
+		{`79°58′56″W, 40°26′46″S`, "40° 26′ 46.0″ S", "79° 58′ 56.0″ W", ""},
+		{`40.446, -79.982`, "40° 26′ 45.6″ N", "79° 58′ 55.2″ W", ""},
+		{`40.446 -79.982`, "40° 26′ 45.6″ N", "79° 58′ 55.2″ W", ""},
+		{`-40.446,79.982`, "40° 26′ 45.6″ S", "79° 58′ 55.2″ E", ""},
+		{`40.446 79.982°W`, "40° 26′ 45.6″ N", "79° 58′ 55.2″ W", ""},
+		{`79.982°E 40.446`, "40° 26′ 45.6″ N", "79° 58′ 55.2″ E", ""},
+
+		{`40.446`, "", "", `1:7: expected degree, got ""`},
+		{`40.446,`, "", "", `1:8: expected degree, got ""`},
+		{`40.446, x`, "", "", `1:9: expected degree, got "x"`},
+		{`40.446, 79.982, 1`, "", "", `1:15: unexpected ","`},
+		{`40°N 79°60′W`, "", "", `1:9: invalid minute "60"`},
+		{`40°N 79°S`, "", "", `1:9: expected longitude, got another latitude`},
+		{`95°N 79°W`, "", "", `1:1: latitude out of range: "95° N"`},
+		{`40, 181`, "", "", `1:5: longitude out of range: "181°"`},
+		{`40°E, 79°W`, "", "", `1:10: expected latitude, got another longitude`},
+	}
+
+	f := NewFormatter(SecUnit, 1)
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			c, err := p.ParseCoordinate(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			lat, lon := f.FormatLat(c.Lat), f.FormatLon(c.Lon)
+			if lat != test.lat || lon != test.lon {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", lat, lon, test.lat, test.lon)
+			}
+		})
+	}
+}
+
+func TestParseSepCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		lat   string
+		lon   string
+		err   string
+	}{
+		{`40 26 46.3 N 79 58 56 W`, "40° 26′ 46.3″ N", "79° 58′ 56.0″ W", ""},
+		{`40 26 46.3, -79 58 56`, "40° 26′ 46.3″ N", "79° 58′ 56.0″ W", ""},
+		{`40:26:46.3 -79:58:56`, "40° 26′ 46.3″ N", "79° 58′ 56.0″ W", ""},
+		{`40-26-46.123N 079-58-56.000W`, "40° 26′ 46.1″ N", "79° 58′ 56.0″ W", ""},
+		{`40.446 -79.982`, "40° 26′ 45.6″ N", "79° 58′ 55.2″ W", ""},
+
+		{`40 26 46 79 58 56`, "", "", `1:10: ambiguous coordinate, expected comma or hemisphere`},
+		{`40 26 -79 58`, "", "", `1:7: ambiguous separator or sign "-"`},
+	}
+
+	f := NewFormatter(SecUnit, 1)
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewParser(NewSepContext(" :-"))
+			c, err := p.ParseCoordinate(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			lat, lon := f.FormatLat(c.Lat), f.FormatLon(c.Lon)
+			if lat != test.lat || lon != test.lon {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", lat, lon, test.lat, test.lon)
+			}
+		})
+	}
+}
+
+func TestParseCoordinateHours(t *testing.T) {
+	tests := []struct {
+		input string
+		lat   string
+		ra    string
+		err   string
+	}{
+		{`14h29m42.9s -62°40′46″`, "62° 40′ 46.0″ S", "14h 29m 42.9s", ""},
+		{`-62°40′46″, 14ʰ29ᵐ42.9ˢ`, "62° 40′ 46.0″ S", "14h 29m 42.9s", ""},
+		{`1h, 10°N`, "10° 0′ 0.0″ N", "1h 0m 0.0s", ""},
+		{`23h59m59.9s, 0°`, "0° 0′ 0.0″ N", "23h 59m 59.9s", ""},
+
+		{`1.5h, 2h`, "", "", `1:7: expected latitude, got another longitude`},
+		{`10°N, 25h`, "", "", `1:7: right ascension out of range: "25h"`},
+		{`10°N, 24h`, "", "", `1:7: right ascension out of range: "24h"`},
+	}
+
+	f := NewFormatter(SecUnit, 1)
+	fh := f.WithHours()
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			c, err := NewDefaultParser().ParseCoordinate(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			lat, ra := f.FormatLat(c.Lat), fh.Format(c.Lon)
+			if lat != test.lat || ra != test.ra {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", lat, ra, test.lat, test.ra)
+			}
+		})
+	}
+}
@@ -30,3 +30,57 @@ func Example_example2() {
 	// Output:
 	// 1° 3.100′ S
 }
+
+func Example_coordinate() {
+	p := dms.NewDefaultParser()
+	c, err := p.ParseCoordinate(`79°58′56″W 40°26′46″N`)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%.6f, %.6f", c.Lat.Degrees(), c.Lon.Degrees())
+
+	// Output:
+	// 40.446111, -79.982222
+}
+
+func Example_findAll() {
+	p := dms.NewDefaultParser()
+	text := "obstacle at 40°26′46″N 79°58′56″W, 250 ft"
+	for _, m := range p.FindAll(text) {
+		fmt.Printf("%v: %v\n", m.StartPos, text[m.Start:m.End])
+	}
+
+	// Output:
+	// 1:13: 40°26′46″N
+	// 1:24: 79°58′56″W
+}
+
+func Example_utm() {
+	p := dms.NewDefaultParser()
+	u, err := p.ParseUTM("17T 630084 4833438")
+	if err != nil {
+		panic(err)
+	}
+	lat, lon, err := u.LatLon()
+	if err != nil {
+		panic(err)
+	}
+	f := dms.NewFormatter(dms.SecUnit, 1)
+	fmt.Println(f.FormatLat(lat), f.FormatLon(lon))
+
+	// Output:
+	// 43° 38′ 33.2″ N 79° 23′ 13.7″ W
+}
+
+func Example_mgrs() {
+	m, err := dms.ToMGRS(dms.NewAngle(48.8582, 0, 0), dms.NewAngle(2.2945, 0, 0))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(dms.NewMGRSFormatter(5).Format(m))
+	fmt.Println(dms.NewMGRSFormatter(3).WithSep(" ").Format(m))
+
+	// Output:
+	// 31UDQ4825111932
+	// 31U DQ 482 119
+}
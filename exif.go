@@ -0,0 +1,222 @@
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/blackchip-org/scan"
+)
+
+// Rational is an unsigned EXIF RATIONAL value
+type Rational struct {
+	Num uint32
+	Den uint32
+}
+
+func (r Rational) String() string {
+	return fmt.Sprintf("%v/%v", r.Num, r.Den)
+}
+
+// EXIFAngle is the value of a GPSLatitude or GPSLongitude tag with its
+// matching reference tag.
+type EXIFAngle struct {
+	Values [3]Rational
+	Ref    string
+}
+
+func (e EXIFAngle) String() string {
+	return fmt.Sprintf("%v, %v, %v %v", e.Values[0], e.Values[1], e.Values[2], e.Ref)
+}
+
+// ToEXIFLat converts a latitude to whole degrees and minutes and seconds
+// with the given denominator. When secDen is zero, the smallest denominator
+// that represents the seconds exactly is used.
+func ToEXIFLat(a Angle, secDen uint32) (EXIFAngle, error) {
+	return toEXIF(a, LatAxis, secDen)
+}
+
+func ToEXIFLon(a Angle, secDen uint32) (EXIFAngle, error) {
+	return toEXIF(a, LonAxis, secDen)
+}
+
+func toEXIF(a Angle, ax Axis, secDen uint32) (EXIFAngle, error) {
+	if !ax.inRange(a) {
+		return EXIFAngle{}, errors.New(ax.rangeError(fmt.Sprint(a.Degrees())))
+	}
+
+	var deg, min, sec *big.Int
+	den := new(big.Int).SetUint64(uint64(secDen))
+	if secDen == 0 {
+		var secRat *big.Rat
+		_, deg, min, secRat = a.split()
+		sec, den = secRat.Num(), secRat.Denom()
+	} else {
+		// Round the whole angle so that a carry propagates into the
+		// minutes and degrees
+		r := new(big.Rat).Abs(a.rat())
+		r.Mul(r, new(big.Rat).SetInt(new(big.Int).Mul(den, big.NewInt(3600))))
+		n, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+		if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+			n.Add(n, big.NewInt(1))
+		}
+		perMin := new(big.Int).Mul(den, big.NewInt(60))
+		deg, n = new(big.Int).QuoRem(n, new(big.Int).Mul(perMin, big.NewInt(60)), new(big.Int))
+		min, sec = new(big.Int).QuoRem(n, perMin, new(big.Int))
+	}
+	if !sec.IsUint64() || sec.Uint64() > math.MaxUint32 || den.Uint64() > math.MaxUint32 {
+		return EXIFAngle{}, fmt.Errorf("seconds cannot be represented as a rational: %v/%v", sec, den)
+	}
+
+	sign := a.Sign()
+	if deg.Sign() == 0 && min.Sign() == 0 && sec.Sign() == 0 {
+		sign = 1
+	}
+	return EXIFAngle{
+		Values: [3]Rational{
+			{Num: uint32(deg.Uint64()), Den: 1},
+			{Num: uint32(min.Uint64()), Den: 1},
+			{Num: uint32(sec.Uint64()), Den: uint32(den.Uint64())},
+		},
+		Ref: hemi(ax, sign),
+	}, nil
+}
+
+// Angle converts the rationals to an angle. The reference letter gives the
+// sign and determines if the value is checked as a latitude or longitude.
+func (e EXIFAngle) Angle() (Angle, error) {
+	ax := hemiAxis(e.Ref)
+	if ax == NoAxis {
+		return Angle{}, fmt.Errorf("invalid reference %v", scan.Quote(e.Ref))
+	}
+	var fields [3]*big.Rat
+	for i, v := range e.Values {
+		if v.Den == 0 {
+			return Angle{}, fmt.Errorf("invalid rational %v", v)
+		}
+		fields[i] = big.NewRat(int64(v.Num), int64(v.Den))
+	}
+	a := newAngleRat(Sign(e.Ref) < 0, fields[0], fields[1], fields[2])
+	if !ax.inRange(a) {
+		return Angle{}, errors.New(ax.rangeError(e.String()))
+	}
+	return a, nil
+}
+
+// ParseXMPLat parses an XMP GPSCoordinate such as "40,26.7767N" or
+// "40,26,46N"
+func ParseXMPLat(v string) (Angle, error) {
+	return parseXMP(v, LatAxis)
+}
+
+func ParseXMPLon(v string) (Angle, error) {
+	return parseXMP(v, LonAxis)
+}
+
+func parseXMP(v string, ax Axis) (Angle, error) {
+	i := 0
+	for i < len(v) && isDigitByte(v[i]) {
+		i++
+	}
+	if i == 0 {
+		return Angle{}, expectedDigit(v, i)
+	}
+	deg, _ := new(big.Rat).SetString(v[:i])
+	if err := expectComma(v, i); err != nil {
+		return Angle{}, err
+	}
+
+	minStart := i + 1
+	i, err := scanDecimal(v, minStart)
+	if err != nil {
+		return Angle{}, err
+	}
+	min, _ := new(big.Rat).SetString(v[minStart:i])
+	if min.Cmp(rat60) >= 0 {
+		return Angle{}, &Error{Pos: posAt(v, minStart), Message: fmt.Sprintf("invalid minute %v", scan.Quote(v[minStart:i]))}
+	}
+
+	sec := new(big.Rat)
+	if i < len(v) && v[i] == ',' {
+		if strings.IndexByte(v[minStart:i], '.') >= 0 {
+			return Angle{}, &Error{Pos: posAt(v, i), Message: `unexpected ","`}
+		}
+		secStart := i + 1
+		if i, err = scanDecimal(v, secStart); err != nil {
+			return Angle{}, err
+		}
+		sec, _ = sec.SetString(v[secStart:i])
+		if sec.Cmp(rat60) >= 0 {
+			return Angle{}, &Error{Pos: posAt(v, secStart), Message: fmt.Sprintf("invalid second %v", scan.Quote(v[secStart:i]))}
+		}
+	}
+
+	h := v[i:]
+	if hemiAxis(h) != ax || len(h) != 1 {
+		return Angle{}, &Error{Pos: posAt(v, i), Message: fmt.Sprintf("invalid %v hemisphere %v", ax, scan.Quote(h))}
+	}
+	a := newAngleRat(Sign(h) < 0, deg, min, sec)
+	if !ax.inRange(a) {
+		return Angle{}, &Error{Pos: posAt(v, 0), Message: ax.rangeError(v)}
+	}
+	return a, nil
+}
+
+func expectComma(v string, i int) error {
+	if i < len(v) && v[i] == ',' {
+		return nil
+	}
+	next := ""
+	if i < len(v) {
+		next = v[i : i+1]
+	}
+	return &Error{Pos: posAt(v, i), Message: fmt.Sprintf(`expected ",", got %v`, scan.Quote(next))}
+}
+
+// XMPFormatter formats an XMP GPSCoordinate using decimal minutes when To
+// is MinUnit or whole minutes and seconds when To is SecUnit. A negative
+// Places writes the last field as the shortest decimal that reads back as
+// the same float64. This gives back values read with ParseXMPLat or
+// ParseXMPLon that have up to 15 significant digits but rounds others, such
+// as a third of a minute.
+type XMPFormatter struct {
+	To     Unit
+	Places int
+}
+
+func NewXMPFormatter(to Unit, places int) XMPFormatter {
+	return XMPFormatter{To: to, Places: places}
+}
+
+func (f XMPFormatter) FormatLat(a Angle) string {
+	return f.format(a, LatAxis)
+}
+
+func (f XMPFormatter) FormatLon(a Angle) string {
+	return f.format(a, LonAxis)
+}
+
+func (f XMPFormatter) format(a Angle, ax Axis) string {
+	to := f.To
+	if to != SecUnit {
+		to = MinUnit
+	}
+	var deg, min *big.Int
+	var last string
+	var zero bool
+	if f.Places >= 0 {
+		deg, min, last, zero = roundFields(a, to, f.Places)
+	} else {
+		deg, min, last, zero = exactFields(a, to)
+	}
+	sign := 1
+	if a.Sign() < 0 && !zero {
+		sign = -1
+	}
+	if to == MinUnit {
+		return fmt.Sprintf("%v,%v%v", deg, padWhole(last, 2), hemi(ax, sign))
+	}
+	return fmt.Sprintf("%v,%02v,%v%v", deg, min, padWhole(last, 2), hemi(ax, sign))
+}
@@ -0,0 +1,247 @@
+package dms
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestToEXIF(t *testing.T) {
+	tests := []struct {
+		a      Angle
+		ax     Axis
+		secDen uint32
+		exif   string
+	}{
+		{NewAngle(40, 26, 46.76), LatAxis, 100, "40/1, 26/1, 4676/100 N"},
+		{NewAngle(-79, 58, 56), LonAxis, 1, "79/1, 58/1, 56/1 W"},
+		{NewAngle(-79, 58, 56.4), LonAxis, 1, "79/1, 58/1, 56/1 W"},
+		{NewAngle(-79, 58, 56.5), LonAxis, 1, "79/1, 58/1, 57/1 W"},
+		{NewAngle(12, 59, 59.9996), LatAxis, 1000, "13/1, 0/1, 0/1000 N"},
+		{NewAngle(-0.0000001, 0, 0), LatAxis, 100, "0/1, 0/1, 0/100 N"},
+		{NewAngle(40.446278, 0, 0), LatAxis, 10000, "40/1, 26/1, 466008/10000 N"},
+		{NewAngleRat(big.NewRat(81, 2), big.NewRat(1, 3), new(big.Rat)), LatAxis, 0, "40/1, 30/1, 20/1 N"},
+		{NewAngleRat(big.NewRat(-1, 7), new(big.Rat), new(big.Rat)), LonAxis, 0, "0/1, 8/1, 240/7 W"},
+		{NewAngle(-90, 0, 0), LatAxis, 0, "90/1, 0/1, 0/1 S"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exif, func(t *testing.T) {
+			var e EXIFAngle
+			var err error
+			if test.ax == LatAxis {
+				e, err = ToEXIFLat(test.a, test.secDen)
+			} else {
+				e, err = ToEXIFLon(test.a, test.secDen)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.String() != test.exif {
+				t.Errorf("\n have: %v \n want: %v", e, test.exif)
+			}
+		})
+	}
+}
+
+func TestToEXIFErrors(t *testing.T) {
+	tests := []struct {
+		a   Angle
+		err string
+	}{
+		{NewAngle(91, 0, 0), `latitude out of range: "91"`},
+		{NewAngleRat(big.NewRat(1, 1<<40), new(big.Rat), new(big.Rat)), "seconds cannot be represented as a rational: 225/68719476736"},
+	}
+	for _, test := range tests {
+		t.Run(test.err, func(t *testing.T) {
+			_, err := ToEXIFLat(test.a, 0)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("\n have err: %v \n want err: %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestEXIFAngle(t *testing.T) {
+	tests := []struct {
+		e   EXIFAngle
+		deg string
+		err string
+	}{
+		{EXIFAngle{[3]Rational{{40, 1}, {26, 1}, {4676, 100}}, "N"}, "40.446322", ""},
+		{EXIFAngle{[3]Rational{{79, 1}, {589333, 10000}, {0, 1}}, "W"}, "-79.982222", ""},
+		{EXIFAngle{[3]Rational{{40, 1}, {26, 1}, {4676, 100}}, "S"}, "-40.446322", ""},
+		{EXIFAngle{[3]Rational{{40, 1}, {26, 0}, {4676, 100}}, "N"}, "", "invalid rational 26/0"},
+		{EXIFAngle{[3]Rational{{40, 1}, {26, 1}, {4676, 100}}, "X"}, "", `invalid reference "X"`},
+		{EXIFAngle{[3]Rational{{90, 1}, {0, 1}, {1, 100}}, "N"}, "", `latitude out of range: "90/1, 0/1, 1/100 N"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.e.String(), func(t *testing.T) {
+			a, err := test.e.Angle()
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestEXIFRoundTrip(t *testing.T) {
+	values := [][3]Rational{
+		{{40, 1}, {26, 1}, {4676, 100}},
+		{{0, 1}, {0, 1}, {1, 100}},
+		{{89, 1}, {59, 1}, {5999, 100}},
+		{{179, 1}, {59, 1}, {5999, 100}},
+	}
+	for _, v := range values {
+		for _, ref := range []string{"N", "S", "E", "W"} {
+			e := EXIFAngle{Values: v, Ref: ref}
+			a, err := e.Angle()
+			if err != nil {
+				continue
+			}
+			var e2 EXIFAngle
+			if hemiAxis(ref) == LatAxis {
+				e2, err = ToEXIFLat(a, 100)
+			} else {
+				e2, err = ToEXIFLon(a, 100)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e2 != e {
+				t.Errorf("\n have: %v \n want: %v", e2, e)
+			}
+			a2, err := e2.Angle()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a2.rat().Cmp(a.rat()) != 0 {
+				t.Errorf("\n have: %v \n want: %v", a2, a)
+			}
+		}
+	}
+}
+
+func TestParseXMP(t *testing.T) {
+	tests := []struct {
+		input string
+		ax    Axis
+		deg   string
+		err   string
+	}{
+		{"40,26.7767N", LatAxis, "40.446278", ""},
+		{"40,26,46N", LatAxis, "40.446111", ""},
+		{"40,26,46.5S", LatAxis, "-40.446250", ""},
+		{"079,58.9333W", LonAxis, "-79.982222", ""},
+		{"0,0.5E", LonAxis, "0.008333", ""},
+
+		{"", LatAxis, "", `1:1: expected digit, got ""`},
+		{"N40,26.7767", LatAxis, "", `1:1: expected digit, got "N"`},
+		{"40 26.7767N", LatAxis, "", `1:3: expected ",", got " "`},
+		{"40,26.7767", LatAxis, "", `1:11: invalid latitude hemisphere ""`},
+		{"40,26.7767E", LatAxis, "", `1:11: invalid latitude hemisphere "E"`},
+		{"40,26.7767NN", LatAxis, "", `1:11: invalid latitude hemisphere "NN"`},
+		{"40,26.5,10N", LatAxis, "", `1:8: unexpected ","`},
+		{"40,60.0N", LatAxis, "", `1:4: invalid minute "60.0"`},
+		{"40,26,60N", LatAxis, "", `1:7: invalid second "60"`},
+		{"40,N", LatAxis, "", `1:4: expected digit, got "N"`},
+		{"91,0N", LatAxis, "", `1:1: latitude out of range: "91,0N"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var a Angle
+			var err error
+			if test.ax == LatAxis {
+				a, err = ParseXMPLat(test.input)
+			} else {
+				a, err = ParseXMPLon(test.input)
+			}
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestFormatXMP(t *testing.T) {
+	tests := []struct {
+		f   XMPFormatter
+		a   Angle
+		ax  Axis
+		str string
+	}{
+		{NewXMPFormatter(MinUnit, 4), NewAngle(40, 26.7767, 0), LatAxis, "40,26.7767N"},
+		{NewXMPFormatter(MinUnit, 4), NewAngle(-79, 58.9333, 0), LonAxis, "79,58.9333W"},
+		{NewXMPFormatter(SecUnit, 0), NewAngle(40, 26, 46), LatAxis, "40,26,46N"},
+		{NewXMPFormatter(SecUnit, 0), NewAngle(-5, 1, 2), LonAxis, "5,01,02W"},
+		{NewXMPFormatter(SecUnit, 1), NewAngle(-5, 59, 59.99), LonAxis, "6,00,00.0W"},
+		{NewXMPFormatter(MinUnit, 2), NewAngle(-0.00001, 0, 0), LatAxis, "0,00.00N"},
+		{NewXMPFormatter(DegUnit, 2), NewAngle(1.5, 0, 0), LatAxis, "1,30.00N"},
+		{NewXMPFormatter(MinUnit, -1), NewAngle(40, 26.7767, 0), LatAxis, "40,26.7767N"},
+		{NewXMPFormatter(SecUnit, -1), NewAngle(-5, 1, 2.5), LonAxis, "5,01,02.5W"},
+		{NewXMPFormatter(MinUnit, -1), NewAngle(0, 0, 20), LatAxis, "0,00.3333333333333333N"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			var str string
+			if test.ax == LatAxis {
+				str = test.f.FormatLat(test.a)
+			} else {
+				str = test.f.FormatLon(test.a)
+			}
+			if str != test.str {
+				t.Errorf("\n have: %v \n want: %v", str, test.str)
+			}
+		})
+	}
+}
+
+func TestXMPRoundTrip(t *testing.T) {
+	tests := []struct {
+		f   XMPFormatter
+		str string
+	}{
+		{NewXMPFormatter(MinUnit, 4), "40,26.7767N"},
+		{NewXMPFormatter(MinUnit, 6), "89,59.999999S"},
+		{NewXMPFormatter(SecUnit, 0), "40,26,46N"},
+		{NewXMPFormatter(SecUnit, 3), "0,00,00.001S"},
+		{NewXMPFormatter(MinUnit, -1), "40,26.7767N"},
+		{NewXMPFormatter(SecUnit, -1), "40,26,46.123N"},
+	}
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			a, err := ParseXMPLat(test.str)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if str := test.f.FormatLat(a); str != test.str {
+				t.Errorf("\n have: %v \n want: %v", str, test.str)
+			}
+		})
+	}
+}
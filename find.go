@@ -0,0 +1,163 @@
+package dms
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blackchip-org/scan"
+)
+
+type Match struct {
+	Fields   Fields
+	Angle    Angle
+	Start    int
+	End      int
+	StartPos scan.Pos
+	EndPos   scan.Pos
+}
+
+type CoordinateMatch struct {
+	Lat Match
+	Lon Match
+}
+
+func (p *Parser) FindAll(text string) []Match {
+	var matches []Match
+	pos := newPosTracker(text)
+	for i := 0; i < len(text); {
+		m, ok := p.matchAt(text, i)
+		if !ok {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+			continue
+		}
+		m.StartPos = pos.at(m.Start)
+		m.EndPos = pos.at(m.End)
+		matches = append(matches, m)
+		i = m.End
+	}
+	return matches
+}
+
+func (p *Parser) FindAllCoordinates(text string) []CoordinateMatch {
+	var coords []CoordinateMatch
+	matches := p.FindAll(text)
+	for i := 0; i+1 < len(matches); i++ {
+		m1, m2 := matches[i], matches[i+1]
+		if !isCoordinateGap(text[m1.End:m2.Start]) {
+			continue
+		}
+		ax1, ax2 := fieldsAxis(m1.Fields), fieldsAxis(m2.Fields)
+		if ax1 == NoAxis || ax2 == NoAxis || ax1 == ax2 {
+			continue
+		}
+		if ax1 == LonAxis {
+			m1, m2 = m2, m1
+		}
+		coords = append(coords, CoordinateMatch{Lat: m1, Lon: m2})
+		i++
+	}
+	return coords
+}
+
+func (p *Parser) matchAt(text string, start int) (Match, bool) {
+	if !isMatchStart(text, start) {
+		return Match{}, false
+	}
+	src := text[start:]
+	p.scanner.InitFromString("", src)
+	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+	f, toks, err := parseFields(r, p.ctx)
+	if err != nil {
+		return Match{}, false
+	}
+
+	end := len(trimRightSpace(src[:offsetOf(src, r.This.Pos)]))
+	if !isMatchEnd(src, end) {
+		// Drop a trailing hemisphere that is the start of a word
+		if hemiAxis(f.Hemi) == NoAxis {
+			return Match{}, false
+		}
+		f.Hemi = ""
+		end = len(trimRightSpace(src[:offsetOf(src, toks.Hemi.Pos)]))
+	}
+	if !hasDesignator(f, unitAttached(src, toks.Deg)) {
+		return Match{}, false
+	}
+	a, err := f.angle()
+	if err != nil {
+		return Match{}, false
+	}
+	return Match{
+		Fields: f,
+		Angle:  a,
+		Start:  start,
+		End:    start + end,
+	}, true
+}
+
+// hasDesignator returns true if the fields are clearly an angle. A number
+// with only a letter for a unit, such as "2d" or "24h", is too common in
+// prose to be found without minutes or a hemisphere. Unit words such as
+// "gon" or "rad" are only found when attached to the number as in "100gon".
+func hasDesignator(f Fields, attached bool) bool {
+	if f.Min != "" || hemiAxis(f.Hemi) != NoAxis {
+		return true
+	}
+	if f.Unit != DegUnit {
+		return attached
+	}
+	return f.DegSym == "°" || f.DegSym == "ʰ"
+}
+
+// unitAttached returns true if the number is followed by a unit without any
+// space in between
+func unitAttached(src string, num scan.Token) bool {
+	end := offsetOf(src, num.Pos) + len(num.Lit)
+	ch, _ := utf8.DecodeRuneInString(src[end:])
+	return unicode.IsLetter(ch)
+}
+
+func isMatchStart(text string, i int) bool {
+	ch, size := utf8.DecodeRuneInString(text[i:])
+	if ch == '+' || ch == '-' {
+		next, _ := utf8.DecodeRuneInString(text[i+size:])
+		if !unicode.IsDigit(next) {
+			return false
+		}
+	} else if !unicode.IsDigit(ch) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !(unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '.')
+}
+
+func isMatchEnd(src string, i int) bool {
+	ch, _ := utf8.DecodeRuneInString(src[i:])
+	prev, _ := utf8.DecodeLastRuneInString(src[:i])
+	return !(unicode.IsLetter(prev) && unicode.IsLetter(ch))
+}
+
+func isCoordinateGap(s string) bool {
+	commas := 0
+	for _, ch := range s {
+		switch {
+		case ch == ',':
+			commas++
+		case !unicode.IsSpace(ch):
+			return false
+		}
+	}
+	return commas <= 1
+}
+
+func trimRightSpace(s string) string {
+	for s != "" {
+		ch, size := utf8.DecodeLastRuneInString(s)
+		if !unicode.IsSpace(ch) {
+			break
+		}
+		s = s[:len(s)-size]
+	}
+	return s
+}
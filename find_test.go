@@ -0,0 +1,116 @@
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		text    string
+		matches []string
+	}{
+		{`nothing to see here`, nil},
+		{`page 3 of 12`, nil},
+		{`Position 40°26′46″N 79°58′56″W at 0900`, []string{`40°26′46″N`, `79°58′56″W`}},
+		{`turn to 270° and descend`, []string{`270°`}},
+		{`about 3 West of the 5 S Street`, nil},
+		{`heading 12.5°.`, []string{`12.5°`}},
+		{`A380 at -12°30′ and +4°`, []string{`-12°30′`, `+4°`}},
+		{`stay within 40°North`, []string{`40°`}},
+		{`RA 14h 29m 42.9s, Dec -62°40′46″`, []string{`14h 29m 42.9s`, `-62°40′46″`}},
+		{"line one\nbearing 1° 2′ 3″ S\nend", []string{`1° 2′ 3″ S`}},
+		{`bad 1°60′ value 2°`, []string{`2°`}},
+		{`3days`, nil},
+		{`2d floor`, nil},
+		{`24h service`, nil},
+		{`open 24h, 7d a week`, nil},
+		{`at 14ʰ and 2d 30m`, []string{`14ʰ`, `2d 30m`}},
+		{`5d N`, []string{`5d N`}},
+		{`3 grad students`, nil},
+		{`turned 2 rad`, nil},
+		{`1 tr`, nil},
+		{`at 100gon and -3200mil`, []string{`100gon`, `-3200mil`}},
+		{`0.5rad, 2 radios`, []string{`0.5rad`}},
+		{`100gonzo`, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			p := NewDefaultParser()
+			var matches []string
+			for _, m := range p.FindAll(test.text) {
+				matches = append(matches, test.text[m.Start:m.End])
+			}
+			if strings.Join(matches, "|") != strings.Join(test.matches, "|") {
+				t.Errorf("\n have: %q \n want: %q", matches, test.matches)
+			}
+		})
+	}
+}
+
+func TestFindAllPos(t *testing.T) {
+	text := "NOTAM\nobstacle at 40°26′46″N 079°58′56″W, 250 ft\nsee 1°S"
+	p := NewDefaultParser()
+	matches := p.FindAll(text)
+	if len(matches) != 3 {
+		t.Fatalf("expected 3 matches, got %v", len(matches))
+	}
+	f := NewFormatter(SecUnit, 0)
+	tests := []struct {
+		match  Match
+		str    string
+		start  string
+		end    string
+		fields Fields
+	}{
+		{matches[0], "40° 26′ 46″", "2:13", "2:23", Fields{Deg: "40", DegSym: "°", Min: "26", MinSym: "′", Sec: "46", SecSym: "″", Hemi: "N"}},
+		{matches[1], "79° 58′ 56″", "2:24", "2:35", Fields{Deg: "079", DegSym: "°", Min: "58", MinSym: "′", Sec: "56", SecSym: "″", Hemi: "W"}},
+		{matches[2], "1° 0′ 0″", "3:5", "3:8", Fields{Deg: "1", DegSym: "°", Hemi: "S"}},
+	}
+	for _, test := range tests {
+		m := test.match
+		pos := fmt.Sprintf("%v %v", m.StartPos, m.EndPos)
+		if pos != test.start+" "+test.end {
+			t.Errorf("\n have pos: %v \n want pos: %v %v", pos, test.start, test.end)
+		}
+		if m.Fields != test.fields {
+			t.Errorf("\n have: %+v \n want: %+v", m.Fields, test.fields)
+		}
+		str := f.Format(m.Angle)
+		if m.Angle.Sign() < 0 {
+			str = str[1:]
+		}
+		if str != test.str {
+			t.Errorf("\n have: %v \n want: %v", str, test.str)
+		}
+	}
+}
+
+func TestFindAllCoordinates(t *testing.T) {
+	tests := []struct {
+		text   string
+		coords []string
+	}{
+		{`at 40°26′46″N 79°58′56″W today`, []string{"40.446111 -79.982222"}},
+		{`at 79°58′56″W, 40°26′46″N today`, []string{"40.446111 -79.982222"}},
+		{`from 40°N, 80°W to 41°N, 81°W`, []string{"40.000000 -80.000000", "41.000000 -81.000000"}},
+		{`40°N and 80°W`, nil},
+		{`40° 80°`, nil},
+		{`40°N 41°N`, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			p := NewDefaultParser()
+			var coords []string
+			for _, c := range p.FindAllCoordinates(test.text) {
+				coords = append(coords, fmt.Sprintf("%.6f %.6f", c.Lat.Angle.Degrees(), c.Lon.Angle.Degrees()))
+			}
+			if strings.Join(coords, "|") != strings.Join(test.coords, "|") {
+				t.Errorf("\n have: %q \n want: %q", coords, test.coords)
+			}
+		})
+	}
+}
@@ -2,18 +2,26 @@ package dms
 
 import (
 	"fmt"
-	"math"
+	"math/big"
+	"strconv"
 	"strings"
 )
 
 type Formatter struct {
-	Deg    string
-	Min    string
-	Sec    string
-	Sign   bool
-	Sep    string
-	Places int
-	To     Unit
+	Deg     string
+	Min     string
+	Sec     string
+	Sign    bool
+	Sep     string
+	Places  int
+	To      Unit
+	Hours   bool
+	Decimal string
+	Group   string
+	North   string
+	South   string
+	East    string
+	West    string
 }
 
 func NewFormatter(to Unit, places int) Formatter {
@@ -37,6 +45,30 @@ func (f Formatter) WithSep(sep string) Formatter {
 	return f
 }
 
+// WithLocale uses the decimal separator, digit grouping, and hemisphere
+// labels of the locale. The symbols are also replaced if the locale sets
+// them.
+func (f Formatter) WithLocale(l Locale) Formatter {
+	f.Decimal, f.Group = l.Decimal, l.Group
+	f.North, f.South, f.East, f.West = l.North, l.South, l.East, l.West
+	if l.Deg != "" {
+		f.Deg = l.Deg
+	}
+	if l.Min != "" {
+		f.Min = l.Min
+	}
+	if l.Sec != "" {
+		f.Sec = l.Sec
+	}
+	return f
+}
+
+func (f Formatter) WithHours() Formatter {
+	f.Deg, f.Min, f.Sec = "h", "m", "s"
+	f.Hours = true
+	return f
+}
+
 func (f Formatter) Format(a Angle) string {
 	return f.format(a, NoAxis)
 }
@@ -49,49 +81,121 @@ func (f Formatter) FormatLon(a Angle) string {
 	return f.format(a, LonAxis)
 }
 
-func (f Formatter) format(a Angle, ax axis) string {
-	deg, min, sec := a.DMS()
+func (f Formatter) FormatBearing(b Bearing) string {
+	l := f.locale()
+	from, to := l.label(b.From), l.label(b.To)
+	return fmt.Sprintf("%v%v%v%v%v", from, f.Sep, f.Format(b.Angle), f.Sep, to)
+}
+
+// locale returns the parts of a locale used for formatting
+func (f Formatter) locale() Locale {
+	return Locale{Decimal: f.Decimal, Group: f.Group, North: f.North, South: f.South, East: f.East, West: f.West}
+}
+
+func (f Formatter) format(a Angle, ax Axis) string {
+	if f.Hours {
+		a = newAngle(new(big.Rat).Quo(a.rat(), rat15))
+	}
+
+	var deg, min *big.Int
+	var last string
+	var zero bool
+	if f.Places >= 0 {
+		deg, min, last, zero = roundFields(a, f.To, f.Places)
+	} else {
+		deg, min, last, zero = exactFields(a, f.To)
+	}
 	sign := 1
-	if deg < 0 {
+	if a.Sign() < 0 && !zero {
 		sign = -1
 	}
+	l := f.locale()
+	last = l.number(last)
 
 	var buf strings.Builder
-	if ax != NoAxis {
-		deg = math.Abs(deg)
+	if sign < 0 && ax == NoAxis {
+		buf.WriteString("-")
+	}
+	switch f.To {
+	case DegUnit:
+		fmt.Fprintf(&buf, "%v%v", last, f.Deg)
+	case MinUnit:
+		fmt.Fprintf(&buf, "%v%v%v%v%v", l.number(deg.String()), f.Deg, f.Sep, last, f.Min)
+	case SecUnit:
+		fmt.Fprintf(&buf, "%v%v%v%v%v%v%v%v", l.number(deg.String()), f.Deg, f.Sep, l.number(min.String()), f.Min, f.Sep, last, f.Sec)
+	default:
+		fmt.Fprintf(&buf, "%v%v%v", last, f.Sep, unitSymbol(f.To))
 	}
-
-	func() {
-		if f.To == DegUnit {
-			degs := deg + (min / 60) + (sec / 3600)
-			if f.Places >= 0 {
-				fmt.Fprintf(&buf, "%.*f%v", f.Places, degs, f.Deg)
-			} else {
-				fmt.Fprintf(&buf, "%v%v", degs, f.Deg)
-			}
-			return
-		}
-		fmt.Fprintf(&buf, "%v%v%v", deg, f.Deg, f.Sep)
-		if f.To == MinUnit {
-			mins := math.Abs(min + (sec / 60))
-			if f.Places >= 0 {
-				fmt.Fprintf(&buf, "%.*f%v", f.Places, mins, f.Min)
-			} else {
-				fmt.Fprintf(&buf, "%v%v", mins, f.Min)
-			}
-			return
-		}
-		mins := math.Abs(min)
-		secs := math.Abs(sec)
-		if f.Places >= 0 {
-			fmt.Fprintf(&buf, "%v%v%v%.*f%v", mins, f.Min, f.Sep, f.Places, secs, f.Sec)
-		} else {
-			fmt.Fprintf(&buf, "%v%v%v%v%v", mins, f.Min, f.Sep, secs, f.Sec)
-		}
-	}()
 	if ax != NoAxis {
-		hemi := hemi(ax, sign)
-		fmt.Fprintf(&buf, "%v%v", f.Sep, hemi)
+		fmt.Fprintf(&buf, "%v%v", f.Sep, l.label(hemi(ax, sign)))
 	}
 	return buf.String()
 }
+
+func unitSymbol(u Unit) string {
+	switch u {
+	case GradUnit:
+		return "gon"
+	case MilUnit:
+		return "mil"
+	case TurnUnit:
+		return "tr"
+	case RadUnit:
+		return "rad"
+	}
+	return ""
+}
+
+// roundFields rounds the magnitude of the angle to the number of places in
+// the last unit before splitting it into fields so that any carry
+// propagates into the minutes and degrees.
+func roundFields(a Angle, to Unit, places int) (deg *big.Int, min *big.Int, last string, zero bool) {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+
+	r := new(big.Rat).Abs(a.rat())
+	r.Mul(r, unitScale(to))
+	r.Mul(r, new(big.Rat).SetInt(pow))
+	n, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	zero = n.Sign() == 0
+
+	whole, frac := new(big.Int).QuoRem(n, pow, new(big.Int))
+	sixty := big.NewInt(60)
+	switch to {
+	case MinUnit:
+		deg, whole = new(big.Int).QuoRem(whole, sixty, new(big.Int))
+	case SecUnit:
+		deg, whole = new(big.Int).QuoRem(whole, big.NewInt(3600), new(big.Int))
+		min, whole = new(big.Int).QuoRem(whole, sixty, new(big.Int))
+	}
+	last = whole.String()
+	if places > 0 {
+		last = fmt.Sprintf("%v.%0*v", last, places, frac)
+	}
+	return
+}
+
+// exactFields splits the angle into fields without rounding to a number of
+// places. The last field is written as the shortest decimal that reads back
+// as the same float64 so that angles made from a float are not written with
+// their full binary expansion.
+func exactFields(a Angle, to Unit) (deg *big.Int, min *big.Int, last string, zero bool) {
+	zero = a.Sign() == 0
+	_, deg, min, sec := a.split()
+	var r *big.Rat
+	switch to {
+	case MinUnit:
+		r = new(big.Rat).Quo(sec, rat60)
+		r.Add(r, new(big.Rat).SetInt(min))
+	case SecUnit:
+		r = sec
+	default:
+		r = new(big.Rat).Abs(a.rat())
+		r.Mul(r, unitScale(to))
+	}
+	v, _ := r.Float64()
+	last = strconv.FormatFloat(v, 'f', -1, 64)
+	return
+}
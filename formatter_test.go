@@ -1,6 +1,10 @@
 package dms
 
-import "testing"
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
 
 func TestFormat(t *testing.T) {
 	var (
@@ -46,6 +50,11 @@ func TestFormat(t *testing.T) {
 
 		{&ddn, NewAngle(1, 0, 0), "1°"},
 		{&mash, NewAngle(1, 2, 3.33), "1°2′3.3″"},
+
+		{&dd, NewAngle(-1, 3, 9), "-1.052500°"},
+		{&dm, NewAngle(-1, 2, 6), "-1° 2.100′"},
+		{&def, NewAngle(-0.5, 0, 0), "-0° 30′ 0.0″"},
+		{&def, NewAngle(-0, 0, 0.01), "0° 0′ 0.0″"},
 	}
 
 	for _, test := range tests {
@@ -58,6 +67,36 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		to     Unit
+		places int
+		angle  Angle
+		result string
+	}{
+		{GradUnit, 2, NewAngle(90, 0, 0), "100.00 gon"},
+		{GradUnit, 4, NewAngle(-1, 0, 0), "-1.1111 gon"},
+		{GradUnit, -1, NewAngle(45, 0, 0), "50 gon"},
+		{MilUnit, 0, NewAngle(90, 0, 0), "1600 mil"},
+		{MilUnit, 1, NewAngle(10, 30, 0), "186.7 mil"},
+		{MilUnit, 0, NewAngle(-0, 0, 1), "0 mil"},
+		{TurnUnit, 3, NewAngle(270, 0, 0), "0.750 tr"},
+		{TurnUnit, -1, NewAngle(-540, 0, 0), "-1.5 tr"},
+		{RadUnit, 6, NewAngle(180, 0, 0), "3.141593 rad"},
+		{RadUnit, 3, NewAngle(-57, 17, 45), "-1.000 rad"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			f := NewFormatter(test.to, test.places)
+			result := f.Format(test.angle)
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+}
+
 func TestFormatLat(t *testing.T) {
 	var (
 		def  = NewFormatter(SecUnit, 1)
@@ -89,6 +128,7 @@ func TestFormatLat(t *testing.T) {
 		{&def, NewAngle(0, 0, 75), "0° 1′ 15.0″ N"},
 		{&def, NewAngle(0, 10, 135), "0° 12′ 15.0″ N"},
 		{&def, NewAngle(0, 59, 135), "1° 1′ 15.0″ N"},
+		{&def, NewAngle(-0.5, 0, 0), "0° 30′ 0.0″ S"},
 
 		{&dms, NewAngle(11, 22, 33.39), "11d 22m 33.4 N"},
 
@@ -135,3 +175,99 @@ func TestFormatLon(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatHours(t *testing.T) {
+	var (
+		hms = NewFormatter(SecUnit, 1).WithHours()
+		hm  = NewFormatter(MinUnit, 3).WithHours()
+		h   = NewFormatter(DegUnit, 4).WithHours()
+		sup = hms.WithSymbols("ʰ", "ᵐ", "ˢ").WithSep("")
+	)
+
+	tests := []struct {
+		f      *Formatter
+		angle  Angle
+		result string
+	}{
+		{&hms, NewAngleHMS(14, 29, 42.9), "14h 29m 42.9s"},
+		{&hms, NewAngle(217, 25, 43.5), "14h 29m 42.9s"},
+		{&hms, NewAngle(15, 0, 0), "1h 0m 0.0s"},
+		{&hm, NewAngleHMS(14, 29, 42.9), "14h 29.715m"},
+		{&h, NewAngle(90, 0, 0), "6.0000h"},
+		{&sup, NewAngleHMS(5, 6, 7.8), "5ʰ6ᵐ7.8ˢ"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result := test.f.Format(test.angle)
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+}
+
+func TestFormatCarry(t *testing.T) {
+	tests := []struct {
+		f      Formatter
+		angle  Angle
+		result string
+	}{
+		{NewFormatter(SecUnit, 0), NewAngle(1, 59, 59.7), "2° 0′ 0″"},
+		{NewFormatter(SecUnit, 0), NewAngle(-1, 59, 59.7), "-2° 0′ 0″"},
+		{NewFormatter(SecUnit, 1), NewAngle(1, 59, 59.96), "2° 0′ 0.0″"},
+		{NewFormatter(SecUnit, 0), NewAngle(1, 58, 59.5), "1° 59′ 0″"},
+		{NewFormatter(SecUnit, 0), NewAngle(359, 59, 59.9), "360° 0′ 0″"},
+		{NewFormatter(MinUnit, 0), NewAngle(1, 59, 45), "2° 0′"},
+		{NewFormatter(MinUnit, 2), NewAngle(1, 59, 59.9), "2° 0.00′"},
+		{NewFormatter(DegUnit, 2), NewAngle(1, 59, 59.9), "2.00°"},
+		{NewFormatter(SecUnit, 0).WithHours(), NewAngleHMS(1, 59, 59.7), "2h 0m 0s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result := test.f.Format(test.angle)
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+}
+
+// TestFormatSweep formats many angles at many precisions and checks that
+// each result parses back into valid fields and is within half a unit in the
+// last place of the original angle.
+func TestFormatSweep(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	p := NewDefaultParser()
+	for i := 0; i < 500; i++ {
+		a := NewAngle(rnd.Float64()*360-180, 0, 0)
+		if i%4 == 0 {
+			// values near a carry boundary
+			a = NewAngle(float64(rnd.Intn(180)), 59, 59.5+rnd.Float64()/2)
+		}
+		for _, to := range []Unit{DegUnit, MinUnit, SecUnit} {
+			for places := 0; places <= 6; places++ {
+				f := NewFormatter(to, places)
+				for _, ax := range []Axis{NoAxis, LatAxis, LonAxis} {
+					str := f.format(a, ax)
+					b, err := p.Parse(str)
+					if err != nil {
+						t.Fatalf("%v: %v", str, err)
+					}
+					if ax != NoAxis && a.Sign() < 0 && b.Sign() > 0 {
+						t.Fatalf("%v: expected negative hemisphere", str)
+					}
+					diff := new(big.Rat).Sub(a.rat(), b.rat())
+					diff.Abs(diff)
+					diff.Mul(diff, unitScale(to))
+					limit := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil))
+					limit.Quo(limit, big.NewRat(2, 1))
+					if diff.Cmp(limit) > 0 {
+						t.Fatalf("%v: off by %v units from %v", str, diff.FloatString(places+2), a)
+					}
+				}
+			}
+		}
+	}
+}
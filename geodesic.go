@@ -0,0 +1,214 @@
+package dms
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// IUGG mean radius of the earth in metres
+	meanEarthRadius = 6371008.8
+
+	vincentyMaxIterations = 200
+	vincentyTolerance     = 1e-12
+)
+
+// InverseSolution is the distance in metres between two points, the
+// azimuth at the start towards the end, and the azimuth at the end back
+// towards the start. Azimuths are in the range [0, 360).
+type InverseSolution struct {
+	Distance    float64
+	Azimuth     Angle
+	BackAzimuth Angle
+}
+
+// ConvergenceError is returned when an iterative solution does not converge,
+// which happens for nearly antipodal points.
+type ConvergenceError struct {
+	Iterations int
+}
+
+func (e ConvergenceError) Error() string {
+	return fmt.Sprintf("failed to converge after %v iterations", e.Iterations)
+}
+
+// azimuth returns the direction of a vector with finite components in the
+// range [0, 360)
+func azimuth(y float64, x float64) Angle {
+	return newAngleUnit(math.Atan2(y, x), RadUnit).Wrap360()
+}
+
+// Haversine solves the inverse problem on a sphere with the mean radius of
+// the earth.
+func Haversine(from Coordinate, to Coordinate) InverseSolution {
+	sinLat1, cosLat1 := from.Lat.sincos()
+	sinLat2, cosLat2 := to.Lat.sincos()
+	sinDLon, cosDLon := to.Lon.Sub(from.Lon).sincos()
+	dLat := to.Lat.Sub(from.Lat).Radians()
+	dLon := Diff(from.Lon, to.Lon).Radians()
+
+	h := math.Pow(math.Sin(dLat/2), 2) + cosLat1*cosLat2*math.Pow(math.Sin(dLon/2), 2)
+	dist := 2 * meanEarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+
+	return InverseSolution{
+		Distance:    dist,
+		Azimuth:     azimuth(sinDLon*cosLat2, cosLat1*sinLat2-sinLat1*cosLat2*cosDLon),
+		BackAzimuth: azimuth(-sinDLon*cosLat1, cosLat2*sinLat1-sinLat2*cosLat1*cosDLon),
+	}
+}
+
+// Vincenty solves the inverse problem on the WGS84 ellipsoid. A
+// ConvergenceError is returned for points that are nearly antipodal. Karney's
+// method, which handles those points, is not implemented.
+func Vincenty(from Coordinate, to Coordinate) (InverseSolution, error) {
+	a, f := wgs84A, wgs84F
+	b := a * (1 - f)
+
+	L := Diff(from.Lon, to.Lon).Radians()
+	U1 := math.Atan((1 - f) * math.Tan(from.Lat.Radians()))
+	U2 := math.Atan((1 - f) * math.Tan(to.Lat.Radians()))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinLambda, cosLambda, sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < vincentyMaxIterations; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return InverseSolution{}, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// Zero on an equatorial line
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi {
+			return InverseSolution{}, &ConvergenceError{Iterations: i + 1}
+		}
+		if math.Abs(lambda-prev) < vincentyTolerance {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return InverseSolution{}, &ConvergenceError{Iterations: vincentyMaxIterations}
+	}
+
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return InverseSolution{
+		Distance:    b * A * (sigma - deltaSigma),
+		Azimuth:     azimuth(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda),
+		BackAzimuth: azimuth(-cosU1*sinLambda, sinU1*cosU2-cosU1*sinU2*cosLambda),
+	}, nil
+}
+
+// DirectSolution is the destination reached from a start point and the
+// azimuth of travel on arrival in the range [0, 360).
+type DirectSolution struct {
+	Dest         Coordinate
+	FinalAzimuth Angle
+}
+
+func newDest(lat float64, lon float64) Coordinate {
+	return NewCoordinate(NewAngle(lat/pi180, 0, 0), NewAngle(lon/pi180, 0, 0).Wrap180())
+}
+
+// SphericalDirect finds the destination after travelling the distance in
+// metres along a great circle on a sphere with the mean radius of the earth.
+// An error is returned if the distance is not finite.
+func SphericalDirect(from Coordinate, az Angle, distance float64) (DirectSolution, error) {
+	if err := checkDistance(distance); err != nil {
+		return DirectSolution{}, err
+	}
+	delta := distance / meanEarthRadius
+	sinLat1, cosLat1 := from.Lat.sincos()
+	sinAz, cosAz := az.sincos()
+	sinDelta, cosDelta := math.Sincos(delta)
+
+	// Rounding can put a point at a pole just outside of [-1, 1]
+	sinLat2 := math.Max(-1, math.Min(1, sinLat1*cosDelta+cosLat1*sinDelta*cosAz))
+	lat2 := math.Asin(sinLat2)
+	dLon := math.Atan2(sinAz*sinDelta*cosLat1, cosDelta-sinLat1*sinLat2)
+	cosLat2 := math.Cos(lat2)
+	sinDLon, cosDLon := math.Sincos(dLon)
+
+	return DirectSolution{
+		Dest:         newDest(lat2, from.Lon.Radians()+dLon),
+		FinalAzimuth: azimuth(sinDLon*cosLat1, sinLat2*cosLat1*cosDLon-cosLat2*sinLat1),
+	}, nil
+}
+
+func checkDistance(distance float64) error {
+	if math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return fmt.Errorf("distance: %w: %v", ErrNotFinite, distance)
+	}
+	return nil
+}
+
+// VincentyDirect finds the destination after travelling the distance in
+// metres along a geodesic on the WGS84 ellipsoid. An error is returned if
+// the distance is not finite.
+func VincentyDirect(from Coordinate, az Angle, distance float64) (DirectSolution, error) {
+	if err := checkDistance(distance); err != nil {
+		return DirectSolution{}, err
+	}
+	a, f := wgs84A, wgs84F
+	b := a * (1 - f)
+
+	sinAlpha1, cosAlpha1 := az.sincos()
+	tanU1 := (1 - f) * math.Tan(from.Lat.Radians())
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	sigma := distance / (b * A)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	converged := false
+	for i := 0; i < vincentyMaxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		prev := sigma
+		sigma = distance/(b*A) + deltaSigma
+		if math.Abs(sigma-prev) < vincentyTolerance {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return DirectSolution{}, &ConvergenceError{Iterations: vincentyMaxIterations}
+	}
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sincos(sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-f)*math.Hypot(sinAlpha, x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+	L := lambda - (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	return DirectSolution{
+		Dest:         newDest(lat2, from.Lon.Radians()+L),
+		FinalAzimuth: azimuth(sinAlpha, -x),
+	}, nil
+}
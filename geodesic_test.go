@@ -0,0 +1,230 @@
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func mustCoordinate(t *testing.T, v string) Coordinate {
+	t.Helper()
+	c, err := NewDefaultParser().ParseCoordinate(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		distance float64
+		azimuth  string
+		back     string
+	}{
+		// Flinders Peak to Buninyong, from Vincenty (1975)
+		{
+			`37°57′03.72030″S 144°25′29.52440″E`, `37°39′10.15610″S 143°55′35.38390″E`,
+			54972.271, "306° 52′ 5.37″", "127° 10′ 25.07″",
+		},
+		// GeographicLib values from Karney, "Algorithms for geodesics" (2013)
+		{`-30.12345, 0`, `-30.12344, 0.00005`, 4.944208, "77° 2′ 36.72″", "257° 2′ 36.63″"},
+		{`40, 0`, `41.79331020506, 137.84490004377`, 10000000, "30° 0′ 0.00″", "329° 5′ 24.61″"},
+		{`0, 0`, `0, 1`, 111319.491, "90° 0′ 0.00″", "270° 0′ 0.00″"},
+		{`0, 0`, `1, 0`, 110574.389, "0° 0′ 0.00″", "180° 0′ 0.00″"},
+		{`0, 0`, `90, 0`, 10001965.729, "0° 0′ 0.00″", "180° 0′ 0.00″"},
+		{`0, 0`, `0, 0`, 0, "0° 0′ 0.00″", "0° 0′ 0.00″"},
+		{`0, 179.5`, `0, -179.5`, 111319.491, "90° 0′ 0.00″", "270° 0′ 0.00″"},
+		// Nearly antipodal but still converges
+		{`0, 0`, `0.5, 179.5`, 19936288.579, "25° 40′ 18.74″", "334° 19′ 37.51″"},
+	}
+
+	f := NewFormatter(SecUnit, 2)
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.to), func(t *testing.T) {
+			s, err := Vincenty(mustCoordinate(t, test.from), mustCoordinate(t, test.to))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(s.Distance-test.distance) > 0.001 {
+				t.Errorf("\n have distance: %.4f \n want distance: %.4f", s.Distance, test.distance)
+			}
+			az, back := f.Format(s.Azimuth), f.Format(s.BackAzimuth)
+			if az != test.azimuth || back != test.back {
+				t.Errorf("\n have: %v, %v \n want: %v, %v", az, back, test.azimuth, test.back)
+			}
+		})
+	}
+}
+
+func TestVincentyAntipodal(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+	}{
+		{`0, 0`, `0.5, 179.7`},
+		// GeographicLib gives 19989832.828 m from Karney (2013)
+		{`-30, 0`, `29.9, 179.8`},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.to), func(t *testing.T) {
+			_, err := Vincenty(mustCoordinate(t, test.from), mustCoordinate(t, test.to))
+			var cerr *ConvergenceError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("expected convergence error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		distance float64
+		azimuth  float64
+		back     float64
+	}{
+		{`0, 0`, `0, 1`, 111195.080, 90, 270},
+		{`0, 0`, `90, 0`, 10007557.221, 0, 180},
+		{`0, 0`, `0, 0`, 0, 0, 0},
+		{`51.5, 0`, `38.8, -77.1`, 5918193.239, 288.481323, 49.251189},
+		{`0, 179.5`, `0, -179.5`, 111195.080, 90, 270},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.to), func(t *testing.T) {
+			s := Haversine(mustCoordinate(t, test.from), mustCoordinate(t, test.to))
+			if math.Abs(s.Distance-test.distance) > 0.001 {
+				t.Errorf("\n have distance: %.4f \n want distance: %.4f", s.Distance, test.distance)
+			}
+			if math.Abs(s.Azimuth.Degrees()-test.azimuth) > 1e-6 || math.Abs(s.BackAzimuth.Degrees()-test.back) > 1e-6 {
+				t.Errorf("\n have: %.6f, %.6f \n want: %.6f, %.6f", s.Azimuth.Degrees(), s.BackAzimuth.Degrees(), test.azimuth, test.back)
+			}
+		})
+	}
+}
+
+func TestVincentyDirect(t *testing.T) {
+	tests := []struct {
+		from     string
+		azimuth  string
+		distance float64
+		lat      string
+		lon      string
+		final    string
+	}{
+		// Flinders Peak to Buninyong
+		{
+			`37°57′03.72030″S 144°25′29.52440″E`, `306°52′05.37″`, 54972.271,
+			"37° 39′ 10.156″ S", "143° 55′ 35.384″ E", "307° 10′ 25.07″",
+		},
+		{`0, 0`, `90°`, 111319.491, "0° 0′ 0.000″ N", "1° 0′ 0.000″ E", "90° 0′ 0.00″"},
+		{`0, 0`, `0°`, 110574.389, "1° 0′ 0.000″ N", "0° 0′ 0.000″ E", "0° 0′ 0.00″"},
+		{`0, 179.5`, `90°`, 111319.491, "0° 0′ 0.000″ N", "179° 30′ 0.000″ W", "90° 0′ 0.00″"},
+		// GeographicLib values from Karney (2013)
+		{`40, 0`, `30°`, 10000000, "41° 47′ 35.917″ N", "137° 50′ 41.640″ E", "149° 5′ 24.61″"},
+	}
+
+	f := NewFormatter(SecUnit, 3)
+	fa := NewFormatter(SecUnit, 2)
+	p := NewDefaultParser()
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.azimuth), func(t *testing.T) {
+			az, err := p.Parse(test.azimuth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := VincentyDirect(mustCoordinate(t, test.from), az, test.distance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat, lon, final := f.FormatLat(s.Dest.Lat), f.FormatLon(s.Dest.Lon), fa.Format(s.FinalAzimuth)
+			if lat != test.lat || lon != test.lon || final != test.final {
+				t.Errorf("\n have: %v, %v, %v \n want: %v, %v, %v", lat, lon, final, test.lat, test.lon, test.final)
+			}
+		})
+	}
+}
+
+func TestSphericalDirect(t *testing.T) {
+	tests := []struct {
+		from     string
+		azimuth  float64
+		distance float64
+		lat      string
+		lon      string
+		final    string
+	}{
+		{`0, 0`, 90, meanEarthRadius * pi180, "0° 0′ 0.000″ N", "1° 0′ 0.000″ E", "90° 0′ 0.000″"},
+		{`0, 0`, 0, meanEarthRadius * math.Pi / 4, "45° 0′ 0.000″ N", "0° 0′ 0.000″ E", "0° 0′ 0.000″"},
+		{`0, 0`, 180, meanEarthRadius * pi180, "1° 0′ 0.000″ S", "0° 0′ 0.000″ E", "180° 0′ 0.000″"},
+		{`0, 179.5`, 90, meanEarthRadius * pi180, "0° 0′ 0.000″ N", "179° 30′ 0.000″ W", "90° 0′ 0.000″"},
+		{`60, 0`, 90, meanEarthRadius * math.Pi / 2, "0° 0′ 0.000″ N", "90° 0′ 0.000″ E", "150° 0′ 0.000″"},
+		// To the pole
+		{`10, 0`, 0, meanEarthRadius * 80 * pi180, "90° 0′ 0.000″ N", "0° 0′ 0.000″ E", "0° 0′ 0.000″"},
+		{`80, 20`, 0, meanEarthRadius * 10 * pi180, "90° 0′ 0.000″ N", "20° 0′ 0.000″ E", "0° 0′ 0.000″"},
+		{`-10, 0`, 180, meanEarthRadius * 80 * pi180, "90° 0′ 0.000″ S", "0° 0′ 0.000″ E", "180° 0′ 0.000″"},
+	}
+
+	f := NewFormatter(SecUnit, 3)
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from, test.azimuth), func(t *testing.T) {
+			s, err := SphericalDirect(mustCoordinate(t, test.from), NewAngle(test.azimuth, 0, 0), test.distance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat, lon, final := f.FormatLat(s.Dest.Lat), f.FormatLon(s.Dest.Lon), f.Format(s.FinalAzimuth)
+			if lat != test.lat || lon != test.lon || final != test.final {
+				t.Errorf("\n have: %v, %v, %v \n want: %v, %v, %v", lat, lon, final, test.lat, test.lon, test.final)
+			}
+		})
+	}
+}
+
+func TestDirectNotFinite(t *testing.T) {
+	from := NewCoordinate(Angle{}, Angle{})
+	for _, dist := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := SphericalDirect(from, Angle{}, dist); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("spherical %v: expected not finite error, got %v", dist, err)
+		}
+		if _, err := VincentyDirect(from, Angle{}, dist); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("vincenty %v: expected not finite error, got %v", dist, err)
+		}
+	}
+}
+
+func TestDirectInverseRoundTrip(t *testing.T) {
+	// Positions agree to within 10 µm
+	const tol = 1e-5
+	from := NewCoordinate(NewAngle(-37, 57, 3.7203), NewAngle(144, 25, 29.5244))
+	for az := 0.0; az < 360; az += 37.5 {
+		for _, dist := range []float64{1, 1000, 1e6, 1e7} {
+			check := func(name string, s InverseSolution) {
+				daz := Diff(s.Azimuth, NewAngle(az, 0, 0)).Radians() * dist
+				if math.Abs(s.Distance-dist) > tol || math.Abs(daz) > tol {
+					t.Errorf("%v %v %v: have %v %v", name, az, dist, s.Distance, s.Azimuth.Degrees())
+				}
+			}
+
+			d, err := VincentyDirect(from, NewAngle(az, 0, 0), dist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := Vincenty(from, d.Dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			check("vincenty", s)
+
+			sd, err := SphericalDirect(from, NewAngle(az, 0, 0), dist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			check("spherical", Haversine(from, sd.Dest))
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blackchip-org/scan"
+)
+
+const (
+	geohashBase32       = "0123456789bcdefghjkmnpqrstuvwxyz"
+	geohashMaxPrecision = 12
+)
+
+type Geohash string
+
+type GeohashCell struct {
+	Lat    Angle
+	Lon    Angle
+	LatErr Angle
+	LonErr Angle
+}
+
+func ToGeohash(lat Angle, lon Angle, precision int) (Geohash, error) {
+	if precision < 1 || precision > geohashMaxPrecision {
+		return "", fmt.Errorf("invalid geohash precision: %v", precision)
+	}
+	latDeg, lonDeg := lat.Degrees(), lon.Degrees()
+	if latDeg < -90 || latDeg > 90 {
+		return "", fmt.Errorf("invalid latitude: %v", latDeg)
+	}
+	// 180 is kept on the eastern edge instead of wrapping to the western one
+	if lonDeg != 180 {
+		lonDeg = wrapLon(lonDeg)
+	}
+
+	latLo, latHi := -90.0, 90.0
+	lonLo, lonHi := -180.0, 180.0
+	var buf strings.Builder
+	bit, ch := 0, 0
+	even := true
+	for buf.Len() < precision {
+		ch <<= 1
+		if even {
+			if mid := (lonLo + lonHi) / 2; lonDeg >= mid {
+				ch |= 1
+				lonLo = mid
+			} else {
+				lonHi = mid
+			}
+		} else {
+			if mid := (latLo + latHi) / 2; latDeg >= mid {
+				ch |= 1
+				latLo = mid
+			} else {
+				latHi = mid
+			}
+		}
+		even = !even
+		if bit++; bit == 5 {
+			buf.WriteByte(geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return Geohash(buf.String()), nil
+}
+
+// ParseGeohash checks that v is a geohash and returns it in lower case
+func ParseGeohash(v string) (Geohash, error) {
+	if v == "" {
+		return "", &Error{Pos: posAt(v, 0), Message: `expected geohash, got ""`}
+	}
+	n := 0
+	for i, ch := range v {
+		if n++; n > geohashMaxPrecision {
+			return "", &Error{Pos: posAt(v, i), Message: fmt.Sprintf("geohash longer than %v characters", geohashMaxPrecision)}
+		}
+		if !strings.ContainsRune(geohashBase32, toLower(ch)) {
+			return "", &Error{Pos: posAt(v, i), Message: fmt.Sprintf("invalid geohash character %v", scan.Quote(string(ch)))}
+		}
+	}
+	return Geohash(strings.ToLower(v)), nil
+}
+
+func toLower(ch rune) rune {
+	if ch >= 'A' && ch <= 'Z' {
+		return ch - 'A' + 'a'
+	}
+	return ch
+}
+
+func (g Geohash) Cell() (GeohashCell, error) {
+	if g == "" || utf8.RuneCountInString(string(g)) > geohashMaxPrecision {
+		return GeohashCell{}, fmt.Errorf("invalid geohash: %v", scan.Quote(string(g)))
+	}
+	latLo, latHi := -90.0, 90.0
+	lonLo, lonHi := -180.0, 180.0
+	even := true
+	for _, ch := range string(g) {
+		v := strings.IndexRune(geohashBase32, toLower(ch))
+		if v < 0 {
+			return GeohashCell{}, fmt.Errorf("invalid geohash: %v", scan.Quote(string(g)))
+		}
+		for mask := 16; mask > 0; mask >>= 1 {
+			if even {
+				mid := (lonLo + lonHi) / 2
+				if v&mask != 0 {
+					lonLo = mid
+				} else {
+					lonHi = mid
+				}
+			} else {
+				mid := (latLo + latHi) / 2
+				if v&mask != 0 {
+					latLo = mid
+				} else {
+					latHi = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return GeohashCell{
+		Lat:    NewAngle((latLo+latHi)/2, 0, 0),
+		Lon:    NewAngle((lonLo+lonHi)/2, 0, 0),
+		LatErr: NewAngle((latHi-latLo)/2, 0, 0),
+		LonErr: NewAngle((lonHi-lonLo)/2, 0, 0),
+	}, nil
+}
+
+func (g Geohash) LatLon() (lat Angle, lon Angle, err error) {
+	c, err := g.Cell()
+	if err != nil {
+		return Angle{}, Angle{}, err
+	}
+	return c.Lat, c.Lon, nil
+}
+
+func (g Geohash) Parent() Geohash {
+	if g == "" {
+		return ""
+	}
+	return g[:len(g)-1]
+}
+
+func (g Geohash) Children() []Geohash {
+	children := make([]Geohash, len(geohashBase32))
+	for i := range geohashBase32 {
+		children[i] = g + Geohash(geohashBase32[i:i+1])
+	}
+	return children
+}
+
+// Neighbor returns the adjacent cell of the same precision that is dlat
+// cells to the north and dlon cells to the east. Longitudes wrap at the
+// antimeridian but there are no cells beyond the poles.
+func (g Geohash) Neighbor(dlat int, dlon int) (Geohash, error) {
+	c, err := g.Cell()
+	if err != nil {
+		return "", err
+	}
+	lat := c.Lat.Degrees() + float64(2*dlat)*c.LatErr.Degrees()
+	lon := c.Lon.Degrees() + float64(2*dlon)*c.LonErr.Degrees()
+	if lat < -90 || lat > 90 {
+		return "", fmt.Errorf("no neighbor beyond the pole for geohash %v", scan.Quote(string(g)))
+	}
+	return ToGeohash(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0), len(g))
+}
+
+// Neighbors returns the eight surrounding cells in the order N, NE, E, SE,
+// S, SW, W, NW. Cells beyond a pole are returned as empty strings.
+func (g Geohash) Neighbors() ([]Geohash, error) {
+	if _, err := g.Cell(); err != nil {
+		return nil, err
+	}
+	dirs := [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	neighbors := make([]Geohash, len(dirs))
+	for i, d := range dirs {
+		neighbors[i], _ = g.Neighbor(d[0], d[1])
+	}
+	return neighbors, nil
+}
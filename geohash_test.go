@@ -0,0 +1,189 @@
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestToGeohash(t *testing.T) {
+	tests := []struct {
+		lat       float64
+		lon       float64
+		precision int
+		hash      Geohash
+	}{
+		{57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{42.605, -5.603, 5, "ezs42"},
+		{0, 0, 1, "s"},
+		{-90, -180, 4, "0000"},
+		{90, 180, 4, "zzzz"},
+		{90, -180, 4, "bpbp"},
+		{-90, 180, 4, "pbpb"},
+		{90, 540, 4, "bpbp"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.hash), func(t *testing.T) {
+			hash, err := ToGeohash(NewAngle(test.lat, 0, 0), NewAngle(test.lon, 0, 0), test.precision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hash != test.hash {
+				t.Errorf("\n have: %v \n want: %v", hash, test.hash)
+			}
+		})
+	}
+}
+
+func TestToGeohashErrors(t *testing.T) {
+	tests := []struct {
+		lat       float64
+		precision int
+		err       string
+	}{
+		{0, 0, "invalid geohash precision: 0"},
+		{0, 13, "invalid geohash precision: 13"},
+		{91, 5, "invalid latitude: 91"},
+	}
+	for _, test := range tests {
+		t.Run(test.err, func(t *testing.T) {
+			_, err := ToGeohash(NewAngle(test.lat, 0, 0), Angle{}, test.precision)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("\n have err: %v \n want err: %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestGeohashCell(t *testing.T) {
+	tests := []struct {
+		hash Geohash
+		cell string
+	}{
+		{"ezs42", "42.60498 -5.60303 0.02197 0.02197"},
+		{"u4pruydqqvj", "57.64911 10.40744 0.00000 0.00000"},
+		{"s", "22.50000 22.50000 22.50000 22.50000"},
+		{"EZS42", "42.60498 -5.60303 0.02197 0.02197"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.hash), func(t *testing.T) {
+			c, err := test.hash.Cell()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cell := fmt.Sprintf("%.5f %.5f %.5f %.5f", c.Lat.Degrees(), c.Lon.Degrees(), c.LatErr.Degrees(), c.LonErr.Degrees())
+			if cell != test.cell {
+				t.Errorf("\n have: %v \n want: %v", cell, test.cell)
+			}
+		})
+	}
+}
+
+func TestGeohashRoundTrip(t *testing.T) {
+	for lat := -90.0; lat <= 90; lat += 7.7 {
+		for lon := -180.0; lon < 180; lon += 13.1 {
+			for precision := 1; precision <= geohashMaxPrecision; precision++ {
+				hash, err := ToGeohash(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0), precision)
+				if err != nil {
+					t.Fatal(err)
+				}
+				c, err := hash.Cell()
+				if err != nil {
+					t.Fatal(err)
+				}
+				dlat := lat - c.Lat.Degrees()
+				dlon := lon - c.Lon.Degrees()
+				if dlat < -c.LatErr.Degrees() || dlat > c.LatErr.Degrees() ||
+					dlon < -c.LonErr.Degrees() || dlon > c.LonErr.Degrees() {
+					t.Errorf("%v: %v %v outside of cell", hash, lat, lon)
+				}
+			}
+		}
+	}
+}
+
+func TestParseGeohash(t *testing.T) {
+	tests := []struct {
+		input string
+		hash  Geohash
+		err   string
+	}{
+		{"ezs42", "ezs42", ""},
+		{"EZS42", "ezs42", ""},
+		{"u4pruydqqvjx", "u4pruydqqvjx", ""},
+
+		{"", "", `1:1: expected geohash, got ""`},
+		{"ezs4a", "", `1:5: invalid geohash character "a"`},
+		{"ézs42", "", `1:1: invalid geohash character "é"`},
+		{"ez i", "", `1:3: invalid geohash character " "`},
+		{"u4pruydqqvjxy", "", `1:13: geohash longer than 12 characters`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			hash, err := ParseGeohash(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if hash != test.hash {
+				t.Errorf("\n have: %v \n want: %v", hash, test.hash)
+			}
+		})
+	}
+}
+
+func TestGeohashParentChildren(t *testing.T) {
+	g := Geohash("ezs42")
+	if parent := g.Parent(); parent != "ezs4" {
+		t.Errorf("\n have: %v \n want: ezs4", parent)
+	}
+	if parent := Geohash("").Parent(); parent != "" {
+		t.Errorf("\n have: %v \n want: empty", parent)
+	}
+	children := g.Children()
+	if len(children) != 32 {
+		t.Fatalf("expected 32 children, got %v", len(children))
+	}
+	for _, child := range children {
+		if child.Parent() != g {
+			t.Errorf("%v: unexpected parent %v", child, child.Parent())
+		}
+	}
+	if children[0] != "ezs420" || children[31] != "ezs42z" {
+		t.Errorf("unexpected children: %v", children)
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	tests := []struct {
+		hash      Geohash
+		neighbors string
+	}{
+		{"ezs42", "ezs48 ezs49 ezs43 ezs41 ezs40 ezefp ezefr ezefx"},
+		{"s", "u v t m k 7 e g"},
+		{"b", "  c 9 8 x z "},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.hash), func(t *testing.T) {
+			neighbors, err := test.hash.Neighbors()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var strs []string
+			for _, n := range neighbors {
+				strs = append(strs, string(n))
+			}
+			have := strings.Join(strs, " ")
+			if have != test.neighbors {
+				t.Errorf("\n have: %v \n want: %v", have, test.neighbors)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package dms
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/blackchip-org/scan"
+)
+
+// ISO6709 is a point in the string representation described in Annex H of
+// ISO 6709, for example "+40.20361-075.00417+350.2CRSWGS_84/".
+type ISO6709 struct {
+	Lat    Angle
+	Lon    Angle
+	Alt    float64
+	HasAlt bool
+	CRS    string
+}
+
+// ParseISO6709 parses a point such as "+40.20361-075.00417+350.2/"
+func ParseISO6709(v string) (ISO6709, error) {
+	var pt ISO6709
+	var err error
+
+	i := 0
+	if pt.Lat, i, err = parseISO6709Angle(v, i, LatAxis); err != nil {
+		return ISO6709{}, err
+	}
+	if pt.Lon, i, err = parseISO6709Angle(v, i, LonAxis); err != nil {
+		return ISO6709{}, err
+	}
+	if i < len(v) && isSignByte(v[i]) {
+		start := i
+		if i, err = scanDecimal(v, i+1); err != nil {
+			return ISO6709{}, err
+		}
+		if pt.Alt, err = strconv.ParseFloat(v[start:i], 64); err != nil {
+			return ISO6709{}, &Error{Pos: posAt(v, start), Message: fmt.Sprintf("invalid altitude %v", scan.Quote(v[start:i]))}
+		}
+		pt.HasAlt = true
+	}
+	if strings.HasPrefix(v[i:], "CRS") {
+		end := strings.IndexByte(v[i:], '/')
+		if end < 0 {
+			end = len(v) - i
+		}
+		pt.CRS = v[i+3 : i+end]
+		if pt.CRS == "" {
+			return ISO6709{}, &Error{Pos: posAt(v, i+3), Message: "expected coordinate reference system"}
+		}
+		i += end
+	}
+	if i < len(v) && v[i] == '/' {
+		i++
+	}
+	if i < len(v) {
+		return ISO6709{}, &Error{Pos: posAt(v, i), Message: fmt.Sprintf("unexpected %v", scan.Quote(v[i:i+1]))}
+	}
+	return pt, nil
+}
+
+func isSignByte(ch byte) bool {
+	return ch == '+' || ch == '-'
+}
+
+func isDigitByte(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// scanDecimal returns the offset after the digits and optional
+// fraction that start at offset i.
+func scanDecimal(v string, i int) (int, error) {
+	start := i
+	for i < len(v) && isDigitByte(v[i]) {
+		i++
+	}
+	if i == start {
+		return i, expectedDigit(v, i)
+	}
+	if i < len(v) && v[i] == '.' {
+		i++
+		frac := i
+		for i < len(v) && isDigitByte(v[i]) {
+			i++
+		}
+		if i == frac {
+			return i, expectedDigit(v, i)
+		}
+	}
+	return i, nil
+}
+
+func expectedDigit(v string, i int) error {
+	next := ""
+	if i < len(v) {
+		next = v[i : i+1]
+	}
+	return &Error{Pos: posAt(v, i), Message: fmt.Sprintf("expected digit, got %v", scan.Quote(next))}
+}
+
+// parseISO6709Angle parses a signed angle in degrees, degrees and minutes,
+// or degrees, minutes, and seconds. The form is given by the number of
+// digits before the decimal point.
+func parseISO6709Angle(v string, start int, ax Axis) (Angle, int, error) {
+	if start >= len(v) || !isSignByte(v[start]) {
+		next := ""
+		if start < len(v) {
+			next = v[start : start+1]
+		}
+		return Angle{}, start, &Error{Pos: posAt(v, start), Message: fmt.Sprintf("expected %v sign, got %v", ax, scan.Quote(next))}
+	}
+	neg := v[start] == '-'
+
+	i := start + 1
+	end, err := scanDecimal(v, i)
+	if err != nil {
+		return Angle{}, end, err
+	}
+	whole := v[i:end]
+	frac := ""
+	if dot := strings.IndexByte(whole, '.'); dot >= 0 {
+		whole, frac = whole[:dot], whole[dot:]
+	}
+
+	width := 2
+	if ax == LonAxis {
+		width = 3
+	}
+	if len(whole) != width && len(whole) != width+2 && len(whole) != width+4 {
+		return Angle{}, i, &Error{Pos: posAt(v, i), Message: fmt.Sprintf("invalid %v %v", ax, scan.Quote(whole))}
+	}
+
+	// The fraction belongs to the last field present
+	fields := []string{whole[:width], "0", "0"}
+	for n, j := 1, width; j < len(whole); n, j = n+1, j+2 {
+		fields[n] = whole[j : j+2]
+	}
+	last := (len(whole) - width) / 2
+	fields[last] += frac
+
+	var rats [3]*big.Rat
+	for n, field := range fields {
+		rats[n], _ = new(big.Rat).SetString(field)
+	}
+	names := []string{"", "minute", "second"}
+	for n := 1; n <= 2; n++ {
+		if rats[n].Cmp(rat60) >= 0 {
+			pos := posAt(v, i+width+(n-1)*2)
+			return Angle{}, i, &Error{Pos: pos, Message: fmt.Sprintf("invalid %v %v", names[n], scan.Quote(fields[n]))}
+		}
+	}
+
+	a := newAngleRat(neg, rats[0], rats[1], rats[2])
+	if !ax.inRange(a) {
+		return Angle{}, start, &Error{Pos: posAt(v, start), Message: ax.rangeError(v[start:end])}
+	}
+	return a, end, nil
+}
+
+type ISO6709Formatter struct {
+	To        Unit
+	Places    int
+	AltPlaces int
+}
+
+func NewISO6709Formatter(to Unit, places int) ISO6709Formatter {
+	return ISO6709Formatter{
+		To:        to,
+		Places:    places,
+		AltPlaces: -1,
+	}
+}
+
+func (f ISO6709Formatter) Format(pt ISO6709) string {
+	var buf strings.Builder
+	buf.WriteString(f.formatAngle(pt.Lat, 2))
+	buf.WriteString(f.formatAngle(pt.Lon, 3))
+	if pt.HasAlt {
+		alt := strconv.FormatFloat(pt.Alt, 'f', f.AltPlaces, 64)
+		if !strings.HasPrefix(alt, "-") {
+			buf.WriteString("+")
+		}
+		buf.WriteString(alt)
+	}
+	if pt.CRS != "" {
+		buf.WriteString("CRS")
+		buf.WriteString(pt.CRS)
+	}
+	buf.WriteString("/")
+	return buf.String()
+}
+
+func (f ISO6709Formatter) formatAngle(a Angle, width int) string {
+	// ISO 6709 only has sexagesimal forms
+	to := f.To
+	if to != DegUnit && to != MinUnit {
+		to = SecUnit
+	}
+	var deg, min *big.Int
+	var last string
+	var zero bool
+	if f.Places >= 0 {
+		deg, min, last, zero = roundFields(a, to, f.Places)
+	} else {
+		deg, min, last, zero = exactFields(a, to)
+	}
+	sign := "+"
+	if a.Sign() < 0 && !zero {
+		sign = "-"
+	}
+
+	switch to {
+	case DegUnit:
+		return sign + padWhole(last, width)
+	case MinUnit:
+		return fmt.Sprintf("%v%0*v%v", sign, width, deg, padWhole(last, 2))
+	}
+	return fmt.Sprintf("%v%0*v%02v%v", sign, width, deg, min, padWhole(last, 2))
+}
+
+// padWhole adds leading zeros to the whole part of a decimal number
+func padWhole(v string, width int) string {
+	whole := v
+	if dot := strings.IndexByte(v, '.'); dot >= 0 {
+		whole = v[:dot]
+	}
+	if n := width - len(whole); n > 0 {
+		return strings.Repeat("0", n) + v
+	}
+	return v
+}
@@ -0,0 +1,128 @@
+package dms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseISO6709(t *testing.T) {
+	tests := []struct {
+		input string
+		point string
+		err   string
+	}{
+		{"+40.20361-075.00417+0350.2CRSWGS_84/", "40.203610 -75.004170 350.2 WGS_84", ""},
+		{"+402646-0795856/", "40.446111 -79.982222", ""},
+		{"+4026.767-07958.933/", "40.446117 -79.982217", ""},
+		{"+402646.12-0795856.34/", "40.446144 -79.982317", ""},
+		{"+0519-00402", "5.316667 -4.033333", ""},
+		{"+27.5916+086.5640+8850CRSWGS_84/", "27.591600 86.564000 8850 WGS_84", ""},
+		{"-90+000/", "-90.000000 0.000000", ""},
+		{"+48.8577+002.295-12.5/", "48.857700 2.295000 -12.5", ""},
+
+		{"", "", `1:1: expected latitude sign, got ""`},
+		{"40.2-075.0/", "", `1:1: expected latitude sign, got "4"`},
+		{"+40.2/", "", `1:6: expected longitude sign, got "/"`},
+		{"+402-0750/", "", `1:2: invalid latitude "402"`},
+		{"+40-0750/", "", `1:5: invalid longitude "0750"`},
+		{"+4060-07500/", "", `1:4: invalid minute "60"`},
+		{"+402660-07500/", "", `1:6: invalid second "60"`},
+		{"+91-075/", "", `1:1: latitude out of range: "+91"`},
+		{"+40-181/", "", `1:4: longitude out of range: "-181"`},
+		{"+40.-075/", "", `1:5: expected digit, got "-"`},
+		{"+40-075+/", "", `1:9: expected digit, got "/"`},
+		{"+40-075CRS/", "", `1:11: expected coordinate reference system`},
+		{"+40-075x", "", `1:8: unexpected "x"`},
+		{"+40-075/x", "", `1:9: unexpected "x"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			pt, err := ParseISO6709(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			point := fmt.Sprintf("%.6f %.6f", pt.Lat.Degrees(), pt.Lon.Degrees())
+			if pt.HasAlt {
+				point += fmt.Sprintf(" %v", pt.Alt)
+			}
+			if pt.CRS != "" {
+				point += " " + pt.CRS
+			}
+			if point != test.point {
+				t.Errorf("\n have: %v \n want: %v", point, test.point)
+			}
+		})
+	}
+}
+
+func TestFormatISO6709(t *testing.T) {
+	pt := ISO6709{
+		Lat: NewAngle(40, 26, 46),
+		Lon: NewAngle(-79, 58, 56),
+	}
+	alt := ISO6709{
+		Lat:    NewAngle(40.20361, 0, 0),
+		Lon:    NewAngle(-75.00417, 0, 0),
+		Alt:    350.2,
+		HasAlt: true,
+		CRS:    "WGS_84",
+	}
+	tests := []struct {
+		f   ISO6709Formatter
+		pt  ISO6709
+		str string
+	}{
+		{NewISO6709Formatter(SecUnit, 0), pt, "+402646-0795856/"},
+		{NewISO6709Formatter(SecUnit, 2), pt, "+402646.00-0795856.00/"},
+		{NewISO6709Formatter(MinUnit, 3), pt, "+4026.767-07958.933/"},
+		{NewISO6709Formatter(DegUnit, 5), pt, "+40.44611-079.98222/"},
+		{NewISO6709Formatter(DegUnit, 5), alt, "+40.20361-075.00417+350.2CRSWGS_84/"},
+		{ISO6709Formatter{To: DegUnit, Places: 1, AltPlaces: 0}, alt, "+40.2-075.0+350CRSWGS_84/"},
+		{NewISO6709Formatter(DegUnit, -1), alt, "+40.20361-075.00417+350.2CRSWGS_84/"},
+		{NewISO6709Formatter(SecUnit, 0), ISO6709{Lat: NewAngle(59.99999, 0, 0), Lon: NewAngle(-5, 0, 0)}, "+600000-0050000/"},
+		{NewISO6709Formatter(DegUnit, 2), ISO6709{Lat: NewAngle(-0.001, 0, 0), Lon: NewAngle(0, 0, 0)}, "+00.00+000.00/"},
+		{NewISO6709Formatter(MinUnit, 0), ISO6709{Lat: NewAngle(-90, 0, 0), Lon: NewAngle(180, 0, 0), Alt: -10, HasAlt: true}, "-9000+18000-10/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			str := test.f.Format(test.pt)
+			if str != test.str {
+				t.Errorf("\n have: %v \n want: %v", str, test.str)
+			}
+		})
+	}
+}
+
+func TestISO6709RoundTrip(t *testing.T) {
+	tests := []struct {
+		f   ISO6709Formatter
+		str string
+	}{
+		{NewISO6709Formatter(DegUnit, 5), "+40.20361-075.00417+350.2CRSWGS_84/"},
+		{NewISO6709Formatter(MinUnit, 3), "-4026.767+07958.933/"},
+		{NewISO6709Formatter(SecUnit, 0), "+402646-0795856/"},
+		{NewISO6709Formatter(SecUnit, 2), "-000000.01+1795959.99-0.5/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			pt, err := ParseISO6709(test.str)
+			if err != nil {
+				t.Fatal(err)
+			}
+			str := test.f.Format(pt)
+			if str != test.str {
+				t.Errorf("\n have: %v \n want: %v", str, test.str)
+			}
+		})
+	}
+}
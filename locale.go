@@ -0,0 +1,148 @@
+package dms
+
+import (
+	"strings"
+	"sync"
+)
+
+// Locale describes how numbers and hemispheres are written in a language.
+// Empty fields fall back to the English defaults. Words maps the names of
+// the hemispheres to NorthType, SouthType, EastType, or WestType for
+// parsing.
+type Locale struct {
+	Name    string
+	Decimal string
+	Group   string
+	North   string
+	South   string
+	East    string
+	West    string
+	Deg     string
+	Min     string
+	Sec     string
+	Words   map[string]string
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{}
+)
+
+func init() {
+	for _, l := range []Locale{
+		// A comma separates the values of a coordinate so it is not
+		// used to group digits
+		{Name: "en", Decimal: ".", North: "N", South: "S", East: "E", West: "W",
+			Words: hemiWords("North", "South", "East", "West")},
+		{Name: "fr", Decimal: ",", Group: "\u202f", North: "N", South: "S", East: "E", West: "O",
+			Words: hemiWords("Nord", "Sud", "Est", "Ouest")},
+		{Name: "es", Decimal: ",", Group: ".", North: "N", South: "S", East: "E", West: "O",
+			Words: hemiWords("Norte", "Sur", "Este", "Oeste")},
+		{Name: "de", Decimal: ",", Group: ".", North: "N", South: "S", East: "O", West: "W",
+			Words: hemiWords("Nord", "Süd", "Ost", "West")},
+		{Name: "pt", Decimal: ",", Group: ".", North: "N", South: "S", East: "L", West: "O",
+			Words: hemiWords("Norte", "Sul", "Leste", "Oeste")},
+		{Name: "ru", Decimal: ",", Group: "\u00a0", North: "с. ш.", South: "ю. ш.", East: "в. д.", West: "з. д.",
+			Words: hemiWords("Север", "Юг", "Восток", "Запад")},
+	} {
+		RegisterLocale(l)
+	}
+}
+
+func hemiWords(north string, south string, east string, west string) map[string]string {
+	return map[string]string{
+		north: NorthType,
+		south: SouthType,
+		east:  EastType,
+		west:  WestType,
+	}
+}
+
+// RegisterLocale adds a locale or replaces the one with the same name
+func RegisterLocale(l Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[l.Name] = l.clone()
+}
+
+// UnregisterLocale removes the locale with the given name
+func UnregisterLocale(name string) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	delete(locales, name)
+}
+
+// LookupLocale returns a copy of the registered locale so that changes to
+// its words do not affect the registry
+func LookupLocale(name string) (Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	l, ok := locales[name]
+	return l.clone(), ok
+}
+
+func (l Locale) clone() Locale {
+	if l.Words == nil {
+		return l
+	}
+	words := make(map[string]string, len(l.Words))
+	for w, t := range l.Words {
+		words[w] = t
+	}
+	l.Words = words
+	return l
+}
+
+// label returns the localized text for a hemisphere designator
+func (l Locale) label(h string) string {
+	var v string
+	switch h {
+	case NorthType:
+		v = l.North
+	case SouthType:
+		v = l.South
+	case EastType:
+		v = l.East
+	case WestType:
+		v = l.West
+	}
+	if v == "" {
+		return h
+	}
+	return v
+}
+
+// number rewrites an unsigned decimal number with the decimal and grouping
+// separators of the locale
+func (l Locale) number(v string) string {
+	whole, frac, hasFrac := strings.Cut(v, ".")
+	if l.Group != "" && len(whole) > 3 {
+		var buf strings.Builder
+		for i, ch := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				buf.WriteString(l.Group)
+			}
+			buf.WriteRune(ch)
+		}
+		whole = buf.String()
+	}
+	if !hasFrac {
+		return whole
+	}
+	return whole + string(l.decimal()) + frac
+}
+
+func (l Locale) decimal() rune {
+	for _, ch := range l.Decimal {
+		return ch
+	}
+	return '.'
+}
+
+// group returns the grouping separator or zero if there is none
+func (l Locale) group() rune {
+	for _, ch := range l.Group {
+		return ch
+	}
+	return 0
+}
@@ -0,0 +1,240 @@
+package dms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		to     Unit
+		places int
+		ax     Axis
+		angle  Angle
+		result string
+	}{
+		{"en", SecUnit, 1, LatAxis, NewAngle(48, 51, 24), "48° 51′ 24.0″ N"},
+		{"fr", SecUnit, 1, LatAxis, NewAngle(48, 51, 24), "48° 51′ 24,0″ N"},
+		{"fr", MinUnit, 2, LonAxis, NewAngle(-3, 30, 0), "3° 30,00′ O"},
+		{"es", DegUnit, 3, LonAxis, NewAngle(-3, 42, 0), "3,700° O"},
+		{"de", SecUnit, 0, LonAxis, NewAngle(13, 24, 18), "13° 24′ 18″ O"},
+		{"de", SecUnit, 0, LonAxis, NewAngle(-13, 24, 18), "13° 24′ 18″ W"},
+		{"pt", DegUnit, 2, LonAxis, NewAngle(9, 8, 0), "9,13° L"},
+		{"ru", DegUnit, 4, LatAxis, NewAngle(-55, 45, 0), "55,7500° ю. ш."},
+		{"en", MilUnit, 0, NoAxis, NewAngle(-90, 0, 0), "-1600 mil"},
+		{"fr", MilUnit, 1, NoAxis, NewAngle(359, 0, 0), "6\u202f382,2 mil"},
+		{"de", SecUnit, 1, NoAxis, NewAngle(1234, 5, 6), "1.234° 5′ 6,0″"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			l, ok := LookupLocale(test.locale)
+			if !ok {
+				t.Fatalf("locale not found: %v", test.locale)
+			}
+			f := NewFormatter(test.to, test.places).WithLocale(l)
+			var result string
+			switch test.ax {
+			case LatAxis:
+				result = f.FormatLat(test.angle)
+			case LonAxis:
+				result = f.FormatLon(test.angle)
+			default:
+				result = f.Format(test.angle)
+			}
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+}
+
+func TestFormatBearingLocale(t *testing.T) {
+	l, _ := LookupLocale("de")
+	f := NewFormatter(DegUnit, 0).WithLocale(l)
+	b := Bearing{From: NorthType, To: EastType, Angle: NewAngle(45, 0, 0)}
+	have := f.FormatBearing(b)
+	want := "N 45° O"
+	if have != want {
+		t.Errorf("\n have: %v \n want: %v", have, want)
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	if _, ok := LookupLocale("xx"); ok {
+		t.Fatalf("unexpected locale: xx")
+	}
+	t.Cleanup(func() { UnregisterLocale("xx") })
+	RegisterLocale(Locale{Name: "xx", Decimal: "·", North: "Nord", Deg: "d", Min: "m", Sec: "s"})
+	l, ok := LookupLocale("xx")
+	if !ok {
+		t.Fatalf("locale not found: xx")
+	}
+	f := NewFormatter(SecUnit, 1).WithLocale(l)
+	have := f.FormatLat(NewAngle(1, 2, 3.4))
+	want := "1d 2m 3·4s Nord"
+	if have != want {
+		t.Errorf("\n have: %v \n want: %v", have, want)
+	}
+	have = f.FormatLat(NewAngle(-1, 2, 3.4))
+	want = "1d 2m 3·4s S"
+	if have != want {
+		t.Errorf("\n have: %v \n want: %v", have, want)
+	}
+	UnregisterLocale("xx")
+	if _, ok := LookupLocale("xx"); ok {
+		t.Errorf("locale not removed: xx")
+	}
+}
+
+func TestLocaleCopy(t *testing.T) {
+	l, _ := LookupLocale("de")
+	f := NewFormatter(SecUnit, 1).WithLocale(l)
+	if f != NewFormatter(SecUnit, 1).WithLocale(l) {
+		t.Errorf("formatters with the same locale are not equal")
+	}
+	l.Words["Nordost"] = NorthType
+	l, _ = LookupLocale("de")
+	if _, ok := l.Words["Nordost"]; ok {
+		t.Errorf("registered locale was changed")
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		input  string
+		axis   Axis
+		deg    string
+		err    string
+	}{
+		{"fr", `48°51′24,5″N`, LatAxis, "48.856806", ""},
+		{"fr", `2,35° est`, LonAxis, "2.350000", ""},
+		{"fr", `3°W`, LonAxis, "-3.000000", ""},
+		{"es", `3°O`, LonAxis, "-3.000000", ""},
+		{"es", `3,5° Oeste`, LonAxis, "-3.500000", ""},
+		{"de", `3°O`, LonAxis, "3.000000", ""},
+		{"de", `13° 24′ 18″ Ost`, LonAxis, "13.405000", ""},
+		{"de", `10° SÜD`, LatAxis, "-10.000000", ""},
+		{"pt", `43° 10′ L`, LonAxis, "43.166667", ""},
+		{"ru", `55,75° Север`, LatAxis, "55.750000", ""},
+		{"en", `40° 26′ 46″ North`, LatAxis, "40.446111", ""},
+		{"en", `100 gon South`, LatAxis, "-90.000000", ""},
+
+		{"de", `1.234,5°`, NoAxis, "1234.500000", ""},
+		{"fr", "6\u202f382,2 mil", NoAxis, "358.998750", ""},
+		{"ru", `55° с. ш.`, LatAxis, "55.000000", ""},
+		{"ru", `37° В. Д.`, LonAxis, "37.000000", ""},
+
+		{"de", `1.5°`, NoAxis, "", `1:1: unexpected "1.5"`},
+		{"de", `1.2345°`, NoAxis, "", `1:1: unexpected "1.2345"`},
+		{"de", `1234.567°`, NoAxis, "", `1:1: unexpected "1234.567"`},
+		{"de", `1° 2.5′`, NoAxis, "", `1:4: unexpected "2.5"`},
+		{"ru", `55° с. д.`, LatAxis, "", `1:5: unexpected "с. д"`},
+		{"fr", `48° Nordest`, LatAxis, "", `1:5: unexpected "Nordest"`},
+		{"es", `3°O`, LatAxis, "", `1:3: invalid latitude hemisphere "O"`},
+		{"de", `10° Ost`, LatAxis, "", `1:5: invalid latitude hemisphere "Ost"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale+" "+test.input, func(t *testing.T) {
+			l, ok := LookupLocale(test.locale)
+			if !ok {
+				t.Fatalf("locale not found: %v", test.locale)
+			}
+			p := NewParser(NewLocaleContext(l, ""))
+			a, err := p.ParseAxis(test.input, test.axis)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestLocaleRoundTrip(t *testing.T) {
+	tests := []struct {
+		to     Unit
+		places int
+		ax     Axis
+		angle  Angle
+	}{
+		{SecUnit, 1, LatAxis, NewAngle(48, 51, 24.5)},
+		{SecUnit, 1, LatAxis, NewAngle(-55, 45, 0)},
+		{MinUnit, 2, LonAxis, NewAngle(-3, 30, 0)},
+		{DegUnit, 4, LonAxis, NewAngle(37, 37, 0)},
+		{SecUnit, 1, NoAxis, NewAngle(1234, 5, 6)},
+		{MilUnit, 1, NoAxis, NewAngle(359, 0, 0)},
+		{MilUnit, 0, NoAxis, NewAngle(-90, 0, 0)},
+	}
+
+	for _, name := range []string{"en", "fr", "es", "de", "pt", "ru"} {
+		l, ok := LookupLocale(name)
+		if !ok {
+			t.Fatalf("locale not found: %v", name)
+		}
+		p := NewParser(NewLocaleContext(l, ""))
+		for _, test := range tests {
+			f := NewFormatter(test.to, test.places).WithLocale(l)
+			format := func(a Angle) string {
+				switch test.ax {
+				case LatAxis:
+					return f.FormatLat(a)
+				case LonAxis:
+					return f.FormatLon(a)
+				}
+				return f.Format(a)
+			}
+			want := format(test.angle)
+			t.Run(name+" "+want, func(t *testing.T) {
+				a, err := p.ParseAxis(want, test.ax)
+				if err != nil {
+					t.Fatal(err)
+				}
+				have := format(a)
+				if have != want {
+					t.Errorf("\n have: %v \n want: %v", have, want)
+				}
+			})
+		}
+	}
+}
+
+func TestParseLocaleSep(t *testing.T) {
+	l, _ := LookupLocale("fr")
+	p := NewParser(NewLocaleContext(l, " :"))
+	a, err := p.ParseFields(`48:51:24,5 N`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Fields{Deg: "48", Min: "51", Sec: "24.5", Hemi: "N", Sep: ":"}
+	if a != want {
+		t.Errorf("\n have: %+v \n want: %+v", a, want)
+	}
+}
+
+func TestParseLocaleCoordinate(t *testing.T) {
+	l, _ := LookupLocale("fr")
+	p := NewParser(NewLocaleContext(l, ""))
+	c, err := p.ParseCoordinate(`48,8566, 2,3522`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := NewFormatter(SecUnit, 1).WithLocale(l)
+	have := f.FormatLat(c.Lat) + ", " + f.FormatLon(c.Lon)
+	want := "48° 51′ 23,8″ N, 2° 21′ 7,9″ E"
+	if have != want {
+		t.Errorf("\n have: %v \n want: %v", have, want)
+	}
+}
@@ -0,0 +1,106 @@
+package dms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxJSONExp limits the exponent of a JSON number so that decoding cannot
+// build a huge rational
+const maxJSONExp = 400
+
+// MarshalText encodes the angle as decimal degrees. Use FormattedAngle to
+// encode with a Formatter.
+func (a Angle) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(a.Degrees(), 'f', -1, 64)), nil
+}
+
+func (a *Angle) UnmarshalText(text []byte) error {
+	v, err := NewDefaultParser().Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// MarshalJSON encodes the angle as a number of decimal degrees
+func (a Angle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Degrees())
+}
+
+// UnmarshalJSON reads a number as exact decimal degrees or a string in any
+// format accepted by the default parser
+func (a *Angle) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return a.UnmarshalText([]byte(text))
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid angle: %s", data)
+	}
+	r, ok := ratNumber(n.String())
+	if !ok {
+		return fmt.Errorf("invalid angle: %s", data)
+	}
+	*a = newAngle(r)
+	return nil
+}
+
+// ratNumber returns the exact value of a JSON number
+func ratNumber(v string) (*big.Rat, bool) {
+	neg := strings.HasPrefix(v, "-")
+	v = strings.TrimPrefix(v, "-")
+	mant, exp, hasExp := strings.Cut(strings.ToLower(v), "e")
+	r := new(big.Rat)
+	if !setDecimal(r, mant) {
+		return nil, false
+	}
+	if hasExp {
+		e, err := strconv.Atoi(exp)
+		if err != nil || e > maxJSONExp || e < -maxJSONExp {
+			return nil, false
+		}
+		if e < 0 {
+			r.Quo(r, pow10(-e))
+		} else {
+			r.Mul(r, pow10(e))
+		}
+	}
+	if neg {
+		r.Neg(r)
+	}
+	return r, true
+}
+
+func pow10(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+// FormattedAngle is an angle that is encoded as text or JSON with its
+// Formatter instead of as decimal degrees. Decoding is the same as for an
+// Angle.
+type FormattedAngle struct {
+	Angle
+	Formatter Formatter
+}
+
+func (a FormattedAngle) MarshalText() ([]byte, error) {
+	return []byte(a.Formatter.Format(a.Angle)), nil
+}
+
+func (a FormattedAngle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Formatter.Format(a.Angle))
+}
@@ -0,0 +1,112 @@
+package dms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	dms := NewFormatter(SecUnit, 1)
+	tests := []struct {
+		value any
+		json  string
+	}{
+		{NewAngle(1, 30, 0), `1.5`},
+		{NewAngle(-12, 15, 0), `-12.25`},
+		{FormattedAngle{NewAngle(1, 3, 6), dms}, `"1° 3′ 6.0″"`},
+		{FormattedAngle{NewAngle(-1, 3, 6), dms}, `"-1° 3′ 6.0″"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			data, err := json.Marshal(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("\n have: %s \n want: %v", data, test.json)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		deg  string
+		err  string
+	}{
+		{`1.5`, "3/2", ""},
+		{`-12.25`, "-49/4", ""},
+		{`40.446111`, "40446111/1000000", ""},
+		{`4.5e1`, "45", ""},
+		{`-25E-2`, "-1/4", ""},
+		{`"1° 3′ 6″ S"`, "-631/600", ""},
+		{`"1d 3m 6s"`, "631/600", ""},
+		{`"14h"`, "210", ""},
+		{`null`, "0", ""},
+
+		{`"1x"`, "", `1:2: unexpected "x"`},
+		{`true`, "", `invalid angle: true`},
+		{`1e1000000`, "", `invalid angle: 1e1000000`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var a Angle
+			err := json.Unmarshal([]byte(test.json), &a)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := a.Rat().RatString()
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestMarshalTextRoundTrip(t *testing.T) {
+	type config struct {
+		Lat Angle `json:"lat"`
+		Lon Angle `json:"lon"`
+	}
+
+	p := NewDefaultParser()
+	in := config{Lat: mustAngle(p.Parse("40.446111")), Lon: mustAngle(p.Parse("-79.982222"))}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("\n have: %v \n want: %v", out, in)
+	}
+
+	type formatted struct {
+		Lat FormattedAngle `json:"lat"`
+	}
+	f := NewFormatter(SecUnit, -1)
+	fin := formatted{Lat: FormattedAngle{NewAngle(40, 26, 46), f}}
+	data, err = json.Marshal(fin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fout := formatted{Lat: FormattedAngle{Formatter: f}}
+	if err := json.Unmarshal(data, &fout); err != nil {
+		t.Fatal(err)
+	}
+	if fout != fin {
+		t.Errorf("\n have: %v \n want: %v", fout, fin)
+	}
+}
@@ -0,0 +1,319 @@
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/blackchip-org/scan"
+)
+
+const (
+	mgrsSquareSize = 100000.0
+	upsBands       = "ABYZ"
+)
+
+var (
+	mgrsCols = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	mgrsRows = [2]string{"ABCDEFGHJKLMNPQRSTUV", "FGHJKLMNPQRSTUVABCDE"}
+
+	// indexed by band A, B, Y, Z
+	upsCols   = [4]string{"JKLPQRSTUXYZ", "ABCFGHJKLPQR", "RSTUXYZ", "ABCFGHJ"}
+	upsMinCol = [4]int{8, 20, 13, 20}
+
+	// indexed by south, north
+	upsRows   = [2]string{"ABCDEFGHJKLMNPQRSTUVWXYZ", "ABCDEFGHJKLMNP"}
+	upsMinRow = [2]int{8, 13}
+)
+
+const LetterType = "letter"
+
+var LetterRule = scan.NewClassRule(isLetter).WithType(LetterType)
+
+var mgrsRuleSet = scan.NewRuleSet(
+	scan.SkipSpaceRule,
+	scan.RealRule,
+	LetterRule,
+)
+
+func isLetter(ch rune) bool {
+	ch = toUpper(ch)
+	return ch >= 'A' && ch <= 'Z'
+}
+
+type MGRS struct {
+	Zone     int
+	Band     string
+	Square   string
+	Easting  float64
+	Northing float64
+}
+
+func (m MGRS) IsPolar() bool {
+	return m.Zone == 0
+}
+
+func (m MGRS) String() string {
+	return NewMGRSFormatter(5).Format(m)
+}
+
+func ToMGRS(lat Angle, lon Angle) (MGRS, error) {
+	latDeg, lonDeg := lat.Degrees(), wrapLon(lon.Degrees())
+	if latDeg < -90 || latDeg > 90 {
+		return MGRS{}, fmt.Errorf("invalid latitude: %v", latDeg)
+	}
+	if latDeg < utmMinLat || latDeg >= utmMaxLat {
+		north, x, y := toUPS(latDeg, lonDeg)
+		return upsToMGRS(north, x, y)
+	}
+	u, err := ToUTM(lat, lon)
+	if err != nil {
+		return MGRS{}, err
+	}
+	return utmToMGRS(u)
+}
+
+func utmToMGRS(u UTM) (MGRS, error) {
+	col := int(math.Floor(u.Easting / mgrsSquareSize))
+	row := int(math.Floor(u.Northing/mgrsSquareSize)) % 20
+	cols := mgrsCols[(u.Zone-1)%3]
+	if col < 1 || col > len(cols) || row < 0 {
+		return MGRS{}, fmt.Errorf("position outside of zone %v", u.Zone)
+	}
+	return MGRS{
+		Zone:     u.Zone,
+		Band:     u.Band,
+		Square:   cols[col-1:col] + mgrsRows[(u.Zone-1)%2][row:row+1],
+		Easting:  math.Mod(u.Easting, mgrsSquareSize),
+		Northing: math.Mod(u.Northing, mgrsSquareSize),
+	}, nil
+}
+
+func upsToMGRS(north bool, x float64, y float64) (MGRS, error) {
+	ix := int(math.Floor(x / mgrsSquareSize))
+	iy := int(math.Floor(y / mgrsSquareSize))
+	band := 0
+	if north {
+		band += 2
+	}
+	if ix >= upsMinCol[1] {
+		band++
+	}
+	hemi := band / 2
+	col, row := ix-upsMinCol[band], iy-upsMinRow[hemi]
+	if col < 0 || col >= len(upsCols[band]) || row < 0 || row >= len(upsRows[hemi]) {
+		return MGRS{}, fmt.Errorf("position outside of polar region")
+	}
+	return MGRS{
+		Band:     upsBands[band : band+1],
+		Square:   upsCols[band][col:col+1] + upsRows[hemi][row:row+1],
+		Easting:  math.Mod(x, mgrsSquareSize),
+		Northing: math.Mod(y, mgrsSquareSize),
+	}, nil
+}
+
+func (m MGRS) LatLon() (lat Angle, lon Angle, err error) {
+	band, square := strings.ToUpper(m.Band), strings.ToUpper(m.Square)
+	if msg := checkGridZone(m.Zone, band); msg != "" {
+		return Angle{}, Angle{}, fmt.Errorf("%v", msg)
+	}
+	if len(square) != 2 {
+		return Angle{}, Angle{}, fmt.Errorf("invalid 100 km square: %v", m.Square)
+	}
+	if msg := checkSquareCol(m.Zone, band, square[0]); msg != "" {
+		return Angle{}, Angle{}, fmt.Errorf("%v", msg)
+	}
+	if msg := checkSquareRow(m.Zone, band, square[1]); msg != "" {
+		return Angle{}, Angle{}, fmt.Errorf("%v", msg)
+	}
+
+	if m.IsPolar() {
+		i := strings.Index(upsBands, band)
+		hemi := i / 2
+		x := float64(strings.IndexByte(upsCols[i], square[0])+upsMinCol[i])*mgrsSquareSize + m.Easting
+		y := float64(strings.IndexByte(upsRows[hemi], square[1])+upsMinRow[hemi])*mgrsSquareSize + m.Northing
+		latDeg, lonDeg := fromUPS(hemi == 1, x, y)
+		return NewAngle(latDeg, 0, 0), NewAngle(lonDeg, 0, 0), nil
+	}
+
+	e100k := float64(strings.IndexByte(mgrsCols[(m.Zone-1)%3], square[0])+1) * mgrsSquareSize
+	n100k := float64(strings.IndexByte(mgrsRows[(m.Zone-1)%2], square[1])) * mgrsSquareSize
+
+	// Row letters repeat every 2000 km. Find the first repetition that is
+	// at or above the lowest northing at the bottom of the latitude band.
+	// Parallels curve toward the pole away from the central meridian so in
+	// the south the lowest northing is at the edge of the zone.
+	bandLat := float64(strings.Index(utmBands, band)-10) * 8
+	_, bandNorthing := tmWGS84.forward(bandLat*pi180, 0)
+	if _, edge := tmWGS84.forward(bandLat*pi180, 3*pi180); edge < bandNorthing {
+		bandNorthing = edge
+	}
+	if bandLat < 0 {
+		bandNorthing += utmFalseNorthing
+	}
+	bandNorthing = math.Floor(bandNorthing/mgrsSquareSize) * mgrsSquareSize
+	northing := n100k + m.Northing
+	for northing < bandNorthing {
+		northing += 2000000
+	}
+
+	u := UTM{Zone: m.Zone, Band: band, Easting: e100k + m.Easting, Northing: northing}
+	return u.LatLon()
+}
+
+func checkGridZone(zone int, band string) string {
+	gzd := fmt.Sprintf("%v%v", zone, band)
+	if zone == 0 {
+		gzd = band
+	}
+	switch {
+	case zone < 0 || zone > 60:
+		return fmt.Sprintf("invalid grid zone %v", scan.Quote(gzd))
+	case zone == 0 && (len(band) != 1 || !strings.Contains(upsBands, band)):
+		return fmt.Sprintf("invalid grid zone %v", scan.Quote(gzd))
+	case zone > 0 && (len(band) != 1 || !strings.Contains(utmBands, band)):
+		return fmt.Sprintf("invalid grid zone %v", scan.Quote(gzd))
+	case band == "X" && (zone == 32 || zone == 34 || zone == 36):
+		return fmt.Sprintf("invalid grid zone %v", scan.Quote(gzd))
+	}
+	return ""
+}
+
+func checkSquareCol(zone int, band string, col byte) string {
+	cols := ""
+	if zone == 0 {
+		cols = upsCols[strings.Index(upsBands, band)]
+	} else {
+		cols = mgrsCols[(zone-1)%3]
+	}
+	if strings.IndexByte(cols, col) < 0 {
+		return fmt.Sprintf("invalid 100 km square column %v", scan.Quote(string(col)))
+	}
+	return ""
+}
+
+func checkSquareRow(zone int, band string, row byte) string {
+	rows := mgrsRows[0]
+	if zone == 0 {
+		rows = upsRows[strings.Index(upsBands, band)/2]
+	}
+	if strings.IndexByte(rows, row) < 0 {
+		return fmt.Sprintf("invalid 100 km square row %v", scan.Quote(string(row)))
+	}
+	return ""
+}
+
+func (p *Parser) ParseMGRS(v string) (MGRS, error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, mgrsRuleSet)
+
+	var m MGRS
+	tok := r.This
+	start := tok
+	if tok.Type == IntType {
+		zone, err := strconv.Atoi(tok.Val)
+		if err != nil || zone < 1 || zone > 60 {
+			return MGRS{}, NewError(tok, "invalid grid zone %v", scan.Quote(tok.Lit))
+		}
+		m.Zone = zone
+		tok = r.Scan()
+	}
+
+	if tok.Type != LetterType {
+		return MGRS{}, NewError(tok, "expected latitude band, got %v", scan.Quote(tok.Lit))
+	}
+	m.Band = strings.ToUpper(tok.Val)
+	if msg := checkGridZone(m.Zone, m.Band); msg != "" {
+		return MGRS{}, NewError(start, "%v", msg)
+	}
+
+	tok = r.Scan()
+	if tok.Type != LetterType {
+		return MGRS{}, NewError(tok, "expected 100 km square, got %v", scan.Quote(tok.Lit))
+	}
+	col := strings.ToUpper(tok.Val)
+	if msg := checkSquareCol(m.Zone, m.Band, col[0]); msg != "" {
+		return MGRS{}, NewError(tok, "%v", msg)
+	}
+
+	tok = r.Scan()
+	if tok.Type != LetterType {
+		return MGRS{}, NewError(tok, "expected 100 km square row, got %v", scan.Quote(tok.Lit))
+	}
+	row := strings.ToUpper(tok.Val)
+	if msg := checkSquareRow(m.Zone, m.Band, row[0]); msg != "" {
+		return MGRS{}, NewError(tok, "%v", msg)
+	}
+	m.Square = col + row
+
+	tok = r.Scan()
+	var east, north string
+	switch {
+	case tok.IsEndOfText():
+		return m, nil
+	case tok.Type != IntType:
+		return MGRS{}, NewError(tok, "expected easting, got %v", scan.Quote(tok.Lit))
+	}
+	digits := tok
+	tok = r.Scan()
+	if tok.Type == IntType {
+		east, north = digits.Val, tok.Val
+		if len(east) != len(north) || len(east) > 5 {
+			return MGRS{}, NewError(tok, "expected northing with %v digits, got %v", len(east), scan.Quote(tok.Lit))
+		}
+		tok = r.Scan()
+	} else {
+		n := len(digits.Val)
+		if n%2 != 0 || n > 10 {
+			return MGRS{}, NewError(digits, "invalid easting and northing %v", scan.Quote(digits.Lit))
+		}
+		east, north = digits.Val[:n/2], digits.Val[n/2:]
+	}
+	if len(east) > 5 {
+		return MGRS{}, NewError(digits, "invalid easting %v", scan.Quote(digits.Lit))
+	}
+	if !tok.IsEndOfText() {
+		return MGRS{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+	}
+
+	scale := math.Pow(10, float64(5-len(east)))
+	e, _ := strconv.Atoi(east)
+	n, _ := strconv.Atoi(north)
+	m.Easting, m.Northing = float64(e)*scale, float64(n)*scale
+	return m, nil
+}
+
+type MGRSFormatter struct {
+	Sep       string
+	Precision int
+}
+
+func NewMGRSFormatter(precision int) MGRSFormatter {
+	return MGRSFormatter{Precision: precision}
+}
+
+func (f MGRSFormatter) WithSep(sep string) MGRSFormatter {
+	f.Sep = sep
+	return f
+}
+
+func (f MGRSFormatter) Format(m MGRS) string {
+	var buf strings.Builder
+	if !m.IsPolar() {
+		fmt.Fprintf(&buf, "%v", m.Zone)
+	}
+	fmt.Fprintf(&buf, "%v%v%v", m.Band, f.Sep, m.Square)
+
+	places := max(0, min(f.Precision, 5))
+	if places == 0 {
+		return buf.String()
+	}
+	// Grid references are truncated, not rounded. The small offset keeps a
+	// value that lands a rounding error below a boundary in the right cell.
+	cell := math.Pow(10, float64(5-places))
+	e := int(math.Floor((m.Easting + 1e-6) / cell))
+	n := int(math.Floor((m.Northing + 1e-6) / cell))
+	fmt.Fprintf(&buf, "%v%0*d%v%0*d", f.Sep, places, e, f.Sep, places, n)
+	return buf.String()
+}
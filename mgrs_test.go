@@ -0,0 +1,198 @@
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToMGRS(t *testing.T) {
+	tests := []struct {
+		lat  Angle
+		lon  Angle
+		mgrs string
+	}{
+		{NewAngle(0, 0, 0), NewAngle(0, 0, 0), "31N AA 66021 00000"},
+		{NewAngle(48.8582, 0, 0), NewAngle(2.2945, 0, 0), "31U DQ 48251 11932"},
+		{NewAngle(43, 38, 33.24), NewAngle(-79, 23, 13.7), "17T PJ 30084 33438"},
+		{NewAngle(-33.857, 0, 0), NewAngle(151.215, 0, 0), "56H LH 34873 52266"},
+		{NewAngle(90, 0, 0), NewAngle(0, 0, 0), "Z AH 00000 00000"},
+		{NewAngle(-90, 0, 0), NewAngle(0, 0, 0), "B AN 00000 00000"},
+		{NewAngle(84, 0, 0), NewAngle(0, 0, 0), "Z AA 00000 33272"},
+		{NewAngle(-85, 0, 0), NewAngle(-10, 0, 0), "A ZT 03545 47018"},
+	}
+
+	f := NewMGRSFormatter(5).WithSep(" ")
+	for _, test := range tests {
+		t.Run(test.mgrs, func(t *testing.T) {
+			m, err := ToMGRS(test.lat, test.lon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := f.Format(m)
+			if result != test.mgrs {
+				t.Errorf("\n have: %v \n want: %v", result, test.mgrs)
+			}
+		})
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	for lat := -90.0; lat <= 90; lat += 3.7 {
+		for lon := -180.0; lon < 180; lon += 11.3 {
+			m, err := ToMGRS(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat2, lon2, err := m.LatLon()
+			if err != nil {
+				t.Fatalf("%v: %v", m, err)
+			}
+			dlon := math.Abs(wrapLon(lon2.Degrees() - lon))
+			if math.Abs(lat) >= 90 {
+				// longitude is undefined at the poles
+				dlon = 0
+			}
+			if math.Abs(lat2.Degrees()-lat) > 1e-9 || dlon > 1e-9 {
+				t.Errorf("%v\n have: %v %v \n want: %v %v", m, lat2.Degrees(), lon2.Degrees(), lat, lon)
+			}
+		}
+	}
+}
+
+// Points on the bottom edge of a band near the edge of a zone have the
+// lowest northings in the band, below the northing on the central meridian
+// in the southern hemisphere
+func TestMGRSBandFloor(t *testing.T) {
+	for lat := -80.0; lat <= 72; lat += 8 {
+		for _, lon := range []float64{0.001, 3, 5.999} {
+			m, err := ToMGRS(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := utmBand(lat); m.Band != want {
+				t.Errorf("%v: have band %v, want %v", m, m.Band, want)
+			}
+			lat2, lon2, err := m.LatLon()
+			if err != nil {
+				t.Fatalf("%v: %v", m, err)
+			}
+			if math.Abs(lat2.Degrees()-lat) > 1e-9 || math.Abs(lon2.Degrees()-lon) > 1e-9 {
+				t.Errorf("%v\n have: %v %v \n want: %v %v", m, lat2.Degrees(), lon2.Degrees(), lat, lon)
+			}
+		}
+	}
+}
+
+func TestParseMGRS(t *testing.T) {
+	tests := []struct {
+		input string
+		mgrs  MGRS
+		err   string
+	}{
+		{`18SUJ2337106519`, MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 23371, Northing: 6519}, ""},
+		{`18S UJ 23371 06519`, MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 23371, Northing: 6519}, ""},
+		{`18SUJ 23371 06519`, MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 23371, Northing: 6519}, ""},
+		{`18suj2306`, MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 23000, Northing: 6000}, ""},
+		{`18S UJ 2 0`, MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 20000, Northing: 0}, ""},
+		{`18SUJ`, MGRS{Zone: 18, Band: "S", Square: "UJ"}, ""},
+		{`04QFJ1234567890`, MGRS{Zone: 4, Band: "Q", Square: "FJ", Easting: 12345, Northing: 67890}, ""},
+		{`ZAH0000000000`, MGRS{Band: "Z", Square: "AH"}, ""},
+		{`B AN 12 34`, MGRS{Band: "B", Square: "AN", Easting: 12000, Northing: 34000}, ""},
+
+		{`61SUJ`, MGRS{}, `1:1: invalid grid zone "61"`},
+		{`18AUJ`, MGRS{}, `1:1: invalid grid zone "18A"`},
+		{`32XMH`, MGRS{}, `1:1: invalid grid zone "32X"`},
+		{`CAH`, MGRS{}, `1:1: invalid grid zone "C"`},
+		{`18`, MGRS{}, `1:3: expected latitude band, got ""`},
+		{`18SAJ`, MGRS{}, `1:4: invalid 100 km square column "A"`},
+		{`18SUW`, MGRS{}, `1:5: invalid 100 km square row "W"`},
+		{`18SIJ`, MGRS{}, `1:4: invalid 100 km square column "I"`},
+		{`ZAW`, MGRS{}, `1:3: invalid 100 km square row "W"`},
+		{`ZKH`, MGRS{}, `1:2: invalid 100 km square column "K"`},
+		{`18SU`, MGRS{}, `1:5: expected 100 km square row, got ""`},
+		{`18SUJ233710651`, MGRS{}, `1:6: invalid easting and northing "233710651"`},
+		{`18SUJ233710651900`, MGRS{}, `1:6: invalid easting and northing "233710651900"`},
+		{`18SUJ 2337 06519`, MGRS{}, `1:12: expected northing with 4 digits, got "06519"`},
+		{`18SUJ 23371 06519 1`, MGRS{}, `1:19: unexpected "1"`},
+		{`18SUJ x`, MGRS{}, `1:7: expected easting, got "x"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			m, err := p.ParseMGRS(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if m != test.mgrs {
+				t.Errorf("\n have: %+v \n want: %+v", m, test.mgrs)
+			}
+		})
+	}
+}
+
+func TestMGRSLatLon(t *testing.T) {
+	tests := []struct {
+		mgrs string
+		lat  float64
+		lon  float64
+	}{
+		{"31U DQ 48251 11932", 48.8582, 2.2945},
+		{"56H LH 34873 52266", -33.857, 151.215},
+		{"17T PJ 30084 33438", 43.642567, -79.387139},
+		{"ZAH0000000000", 90, 0},
+		{"BAN0000000000", -90, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.mgrs, func(t *testing.T) {
+			p := NewDefaultParser()
+			m, err := p.ParseMGRS(test.mgrs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat, lon, err := m.LatLon()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// references are truncated to the metre, about 1e-5 degrees
+			dlat := math.Abs(lat.Degrees() - test.lat)
+			dlon := math.Abs(lon.Degrees() - test.lon)
+			if math.Abs(test.lat) == 90 {
+				dlon = 0
+			}
+			if dlat > 2e-5 || dlon > 2e-5 {
+				t.Errorf("\n have: %.6f %.6f \n want: %.6f %.6f", lat.Degrees(), lon.Degrees(), test.lat, test.lon)
+			}
+		})
+	}
+}
+
+func TestFormatMGRS(t *testing.T) {
+	m := MGRS{Zone: 18, Band: "S", Square: "UJ", Easting: 23371.9, Northing: 6519.2}
+	tests := []struct {
+		f      MGRSFormatter
+		result string
+	}{
+		{NewMGRSFormatter(5), "18SUJ2337106519"},
+		{NewMGRSFormatter(4), "18SUJ23370651"},
+		{NewMGRSFormatter(3), "18SUJ233065"},
+		{NewMGRSFormatter(1), "18SUJ20"},
+		{NewMGRSFormatter(0), "18SUJ"},
+		{NewMGRSFormatter(5).WithSep(" "), "18S UJ 23371 06519"},
+		{NewMGRSFormatter(9), "18SUJ2337106519"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result := test.f.Format(m)
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+}
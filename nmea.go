@@ -0,0 +1,351 @@
+package dms
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blackchip-org/scan"
+)
+
+type GGA struct {
+	Time       time.Time
+	Lat        Angle
+	Lon        Angle
+	Quality    int
+	Satellites int
+	HDOP       float64
+	Alt        float64
+	GeoidSep   float64
+}
+
+type RMC struct {
+	Time   time.Time
+	Valid  bool
+	Lat    Angle
+	Lon    Angle
+	Speed  float64
+	Course Angle
+	MagVar Angle
+}
+
+type GLL struct {
+	Lat   Angle
+	Lon   Angle
+	Time  time.Time
+	Valid bool
+}
+
+// ParseNMEALat parses a latitude field pair such as "4026.7767,N"
+func ParseNMEALat(v string) (Angle, error) {
+	return parseNMEAPair(v, LatAxis)
+}
+
+// ParseNMEALon parses a longitude field pair such as "07958.9333,W"
+func ParseNMEALon(v string) (Angle, error) {
+	return parseNMEAPair(v, LonAxis)
+}
+
+func parseNMEAPair(v string, ax Axis) (Angle, error) {
+	comma := strings.IndexByte(v, ',')
+	if comma < 0 {
+		return Angle{}, &Error{Pos: posAt(v, len(v)), Message: `expected ","`}
+	}
+	if i := strings.LastIndexByte(v, ','); i != comma {
+		return Angle{}, &Error{Pos: posAt(v, i), Message: `unexpected ","`}
+	}
+	return parseNMEAAngle(v, 0, v[:comma], v[comma+1:], ax)
+}
+
+// parseNMEAAngle parses a value in the packed degrees and minutes form and
+// its hemisphere. The value starts at offset in src and the hemisphere
+// follows after a comma.
+func parseNMEAAngle(src string, offset int, val string, hemi string, ax Axis) (Angle, error) {
+	width := 4
+	if ax == LonAxis {
+		width = 5
+	}
+	end, err := scanDecimal(val, 0)
+	if err != nil || end != len(val) {
+		return Angle{}, &Error{Pos: posAt(src, offset), Message: fmt.Sprintf("invalid %v %v", ax, scan.Quote(val))}
+	}
+	whole := val
+	if dot := strings.IndexByte(val, '.'); dot >= 0 {
+		whole = val[:dot]
+	}
+	if len(whole) < 3 || len(whole) > width {
+		return Angle{}, &Error{Pos: posAt(src, offset), Message: fmt.Sprintf("invalid %v %v", ax, scan.Quote(val))}
+	}
+
+	n := len(whole) - 2
+	deg, _ := new(big.Rat).SetString(val[:n])
+	min, _ := new(big.Rat).SetString(val[n:])
+	if min.Cmp(rat60) >= 0 {
+		return Angle{}, &Error{Pos: posAt(src, offset+n), Message: fmt.Sprintf("invalid minute %v", scan.Quote(val[n:]))}
+	}
+
+	hemiPos := posAt(src, offset+len(val)+1)
+	if hemiAxis(hemi) != ax {
+		return Angle{}, &Error{Pos: hemiPos, Message: fmt.Sprintf("invalid %v hemisphere %v", ax, scan.Quote(hemi))}
+	}
+	a := newAngleRat(Sign(hemi) < 0, deg, min, ratZero)
+	if !ax.inRange(a) {
+		return Angle{}, &Error{Pos: posAt(src, offset), Message: ax.rangeError(val)}
+	}
+	return a, nil
+}
+
+type nmeaSentence struct {
+	src     string
+	fields  []string
+	offsets []int
+}
+
+func parseNMEASentence(v string, typ string, nfields int) (nmeaSentence, error) {
+	v = strings.TrimRight(v, "\r\n")
+	if !strings.HasPrefix(v, "$") {
+		next := ""
+		if v != "" {
+			next = v[:1]
+		}
+		return nmeaSentence{}, &Error{Pos: posAt(v, 0), Message: fmt.Sprintf(`expected "$", got %v`, scan.Quote(next))}
+	}
+	star := strings.LastIndexByte(v, '*')
+	if star < 0 {
+		return nmeaSentence{}, &Error{Pos: posAt(v, len(v)), Message: "missing checksum"}
+	}
+	var sum byte
+	for i := 1; i < star; i++ {
+		sum ^= v[i]
+	}
+	have := v[star+1:]
+	want := fmt.Sprintf("%02X", sum)
+	if !strings.EqualFold(have, want) {
+		return nmeaSentence{}, &Error{Pos: posAt(v, star+1), Message: fmt.Sprintf("invalid checksum %v, computed %v", scan.Quote(have), scan.Quote(want))}
+	}
+
+	s := nmeaSentence{src: v}
+	offset := 1
+	for _, field := range strings.Split(v[1:star], ",") {
+		s.fields = append(s.fields, field)
+		s.offsets = append(s.offsets, offset)
+		offset += len(field) + 1
+	}
+	if addr := s.fields[0]; len(addr) != 5 || addr[2:] != typ {
+		return nmeaSentence{}, s.errorf(0, "expected %v sentence, got %v", typ, scan.Quote(addr))
+	}
+	if len(s.fields)-1 < nfields {
+		return nmeaSentence{}, &Error{Pos: posAt(v, star), Message: fmt.Sprintf("expected at least %v fields, got %v", nfields, len(s.fields)-1)}
+	}
+	return s, nil
+}
+
+func (s nmeaSentence) errorf(i int, format string, args ...any) error {
+	return &Error{Pos: posAt(s.src, s.offsets[i]), Message: fmt.Sprintf(format, args...)}
+}
+
+func (s nmeaSentence) float(i int, name string) (float64, error) {
+	if s.fields[i] == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s.fields[i], 64)
+	if err != nil {
+		return 0, s.errorf(i, "invalid %v %v", name, scan.Quote(s.fields[i]))
+	}
+	return v, nil
+}
+
+func (s nmeaSentence) int(i int, name string) (int, error) {
+	if s.fields[i] == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s.fields[i])
+	if err != nil {
+		return 0, s.errorf(i, "invalid %v %v", name, scan.Quote(s.fields[i]))
+	}
+	return v, nil
+}
+
+func (s nmeaSentence) angle(i int, name string) (Angle, error) {
+	if s.fields[i] == "" {
+		return Angle{}, nil
+	}
+	r := new(big.Rat)
+	if !setDecimal(r, s.fields[i]) {
+		return Angle{}, s.errorf(i, "invalid %v %v", name, scan.Quote(s.fields[i]))
+	}
+	return newAngle(r), nil
+}
+
+// time parses a time of day field. The date is left as January 1 of
+// year 0 when the sentence does not have one.
+func (s nmeaSentence) time(i int, date string) (time.Time, error) {
+	if s.fields[i] == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("020106150405", date+s.fields[i])
+	if date == "" {
+		t, err = time.Parse("150405", s.fields[i])
+	}
+	if err != nil {
+		return time.Time{}, s.errorf(i, "invalid time %v", scan.Quote(s.fields[i]))
+	}
+	return t, nil
+}
+
+// position parses the latitude and longitude field pairs that start at
+// field i. All four fields are empty when there is no fix.
+func (s nmeaSentence) position(i int) (lat Angle, lon Angle, err error) {
+	if strings.Join(s.fields[i:i+4], "") == "" {
+		return
+	}
+	lat, err = parseNMEAAngle(s.src, s.offsets[i], s.fields[i], s.fields[i+1], LatAxis)
+	if err != nil {
+		return
+	}
+	lon, err = parseNMEAAngle(s.src, s.offsets[i+2], s.fields[i+2], s.fields[i+3], LonAxis)
+	return
+}
+
+func (s nmeaSentence) status(i int) (bool, error) {
+	switch s.fields[i] {
+	case "A":
+		return true, nil
+	case "V":
+		return false, nil
+	}
+	return false, s.errorf(i, "invalid status %v", scan.Quote(s.fields[i]))
+}
+
+// ParseGGA parses a GGA fix data sentence
+func ParseGGA(v string) (GGA, error) {
+	s, err := parseNMEASentence(v, "GGA", 14)
+	if err != nil {
+		return GGA{}, err
+	}
+	var g GGA
+	if g.Time, err = s.time(1, ""); err != nil {
+		return GGA{}, err
+	}
+	if g.Lat, g.Lon, err = s.position(2); err != nil {
+		return GGA{}, err
+	}
+	if g.Quality, err = s.int(6, "quality"); err != nil {
+		return GGA{}, err
+	}
+	if g.Satellites, err = s.int(7, "satellite count"); err != nil {
+		return GGA{}, err
+	}
+	if g.HDOP, err = s.float(8, "HDOP"); err != nil {
+		return GGA{}, err
+	}
+	if g.Alt, err = s.float(9, "altitude"); err != nil {
+		return GGA{}, err
+	}
+	if g.GeoidSep, err = s.float(11, "geoid separation"); err != nil {
+		return GGA{}, err
+	}
+	return g, nil
+}
+
+// ParseRMC parses an RMC recommended minimum sentence
+func ParseRMC(v string) (RMC, error) {
+	s, err := parseNMEASentence(v, "RMC", 11)
+	if err != nil {
+		return RMC{}, err
+	}
+	var r RMC
+	if s.fields[9] != "" {
+		if _, err := time.Parse("020106", s.fields[9]); err != nil {
+			return RMC{}, s.errorf(9, "invalid date %v", scan.Quote(s.fields[9]))
+		}
+	}
+	if r.Time, err = s.time(1, s.fields[9]); err != nil {
+		return RMC{}, err
+	}
+	if r.Valid, err = s.status(2); err != nil {
+		return RMC{}, err
+	}
+	if r.Lat, r.Lon, err = s.position(3); err != nil {
+		return RMC{}, err
+	}
+	if r.Speed, err = s.float(7, "speed"); err != nil {
+		return RMC{}, err
+	}
+	if r.Course, err = s.angle(8, "course"); err != nil {
+		return RMC{}, err
+	}
+	if r.MagVar, err = s.angle(10, "magnetic variation"); err != nil {
+		return RMC{}, err
+	}
+	if s.fields[10] != "" {
+		switch s.fields[11] {
+		case "E":
+		case "W":
+			r.MagVar = newAngle(new(big.Rat).Neg(r.MagVar.rat()))
+		default:
+			return RMC{}, s.errorf(11, "invalid magnetic variation direction %v", scan.Quote(s.fields[11]))
+		}
+	}
+	return r, nil
+}
+
+// ParseGLL parses a GLL geographic position sentence
+func ParseGLL(v string) (GLL, error) {
+	s, err := parseNMEASentence(v, "GLL", 6)
+	if err != nil {
+		return GLL{}, err
+	}
+	var g GLL
+	if g.Lat, g.Lon, err = s.position(1); err != nil {
+		return GLL{}, err
+	}
+	if g.Time, err = s.time(5, ""); err != nil {
+		return GLL{}, err
+	}
+	if g.Valid, err = s.status(6); err != nil {
+		return GLL{}, err
+	}
+	return g, nil
+}
+
+// NMEAFormatter writes packed degrees and minutes fields. Places is the
+// number of decimal places for the minutes or, as with Formatter, negative
+// for the shortest decimal that reads back as the same float64.
+type NMEAFormatter struct {
+	Places int
+}
+
+func NewNMEAFormatter(places int) NMEAFormatter {
+	return NMEAFormatter{Places: places}
+}
+
+func (f NMEAFormatter) FormatLat(a Angle) string {
+	return f.format(a, LatAxis)
+}
+
+func (f NMEAFormatter) FormatLon(a Angle) string {
+	return f.format(a, LonAxis)
+}
+
+func (f NMEAFormatter) format(a Angle, ax Axis) string {
+	var deg *big.Int
+	var last string
+	var zero bool
+	if f.Places >= 0 {
+		deg, _, last, zero = roundFields(a, MinUnit, f.Places)
+	} else {
+		deg, _, last, zero = exactFields(a, MinUnit)
+	}
+	sign := 1
+	if a.Sign() < 0 && !zero {
+		sign = -1
+	}
+	width := 2
+	if ax == LonAxis {
+		width = 3
+	}
+	return fmt.Sprintf("%0*v%v,%v", width, deg, padWhole(last, 2), hemi(ax, sign))
+}
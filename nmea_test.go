@@ -0,0 +1,283 @@
+package dms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseNMEA(t *testing.T) {
+	tests := []struct {
+		input string
+		ax    Axis
+		deg   string
+		err   string
+	}{
+		{"4026.7767,N", LatAxis, "40.446278", ""},
+		{"4026.7767,S", LatAxis, "-40.446278", ""},
+		{"07958.9333,W", LonAxis, "-79.982222", ""},
+		{"12311.12,E", LonAxis, "123.185333", ""},
+		{"0000.0000,N", LatAxis, "0.000000", ""},
+		{"9000,S", LatAxis, "-90.000000", ""},
+		{"959.9333,W", LonAxis, "-9.998888", ""},
+
+		{"4026.7767", LatAxis, "", `1:10: expected ","`},
+		{"4026.7767,N,", LatAxis, "", `1:12: unexpected ","`},
+		{"4026.7767,W", LatAxis, "", `1:11: invalid latitude hemisphere "W"`},
+		{"4026.7767,", LatAxis, "", `1:11: invalid latitude hemisphere ""`},
+		{"07958.9333,N", LonAxis, "", `1:12: invalid longitude hemisphere "N"`},
+		{"40267767,N", LatAxis, "", `1:1: invalid latitude "40267767"`},
+		{"40.26,N", LatAxis, "", `1:1: invalid latitude "40.26"`},
+		{"4026.,N", LatAxis, "", `1:1: invalid latitude "4026."`},
+		{"4060.0000,N", LatAxis, "", `1:3: invalid minute "60.0000"`},
+		{"9000.0001,N", LatAxis, "", `1:1: latitude out of range: "9000.0001"`},
+		{"18100.0000,E", LonAxis, "", `1:1: longitude out of range: "18100.0000"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var a Angle
+			var err error
+			if test.ax == LatAxis {
+				a, err = ParseNMEALat(test.input)
+			} else {
+				a, err = ParseNMEALon(test.input)
+			}
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestFormatNMEA(t *testing.T) {
+	tests := []struct {
+		a      Angle
+		ax     Axis
+		places int
+		str    string
+	}{
+		{NewAngle(40, 26.7767, 0), LatAxis, 4, "4026.7767,N"},
+		{NewAngle(-79, 58.9333, 0), LonAxis, 4, "07958.9333,W"},
+		{NewAngle(-79, 58.9333, 0), LonAxis, 2, "07958.93,W"},
+		{NewAngle(5, 1.5, 0), LatAxis, 3, "0501.500,N"},
+		{NewAngle(-0.0000001, 0, 0), LatAxis, 4, "0000.0000,N"},
+		{NewAngle(12, 59.99999, 0), LonAxis, 4, "01300.0000,E"},
+		{NewAngle(-90, 0, 0), LatAxis, 0, "9000,S"},
+		{NewAngle(40, 26.7767, 0), LatAxis, -1, "4026.7767,N"},
+		{NewAngle(-5, 1.5, 0), LonAxis, -1, "00501.5,W"},
+		{NewAngle(3, 0, 0), LatAxis, -1, "0300,N"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			f := NewNMEAFormatter(test.places)
+			var str string
+			if test.ax == LatAxis {
+				str = f.FormatLat(test.a)
+			} else {
+				str = f.FormatLon(test.a)
+			}
+			if str != test.str {
+				t.Errorf("\n have: %v \n want: %v", str, test.str)
+			}
+		})
+	}
+}
+
+func TestNMEARoundTrip(t *testing.T) {
+	f := NewNMEAFormatter(4)
+	for _, v := range []string{"4026.7767,N", "0000.0001,S", "8959.9999,S"} {
+		a, err := ParseNMEALat(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if str := f.FormatLat(a); str != v {
+			t.Errorf("\n have: %v \n want: %v", str, v)
+		}
+	}
+	for _, v := range []string{"07958.9333,W", "00000.0001,E", "17959.9999,W"} {
+		a, err := ParseNMEALon(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if str := f.FormatLon(a); str != v {
+			t.Errorf("\n have: %v \n want: %v", str, v)
+		}
+	}
+}
+
+func TestParseGGA(t *testing.T) {
+	tests := []struct {
+		input string
+		gga   string
+		err   string
+	}{
+		{
+			"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+			"12:35:19 48.117300 11.516667 1 8 0.9 545.4 46.9", "",
+		},
+		{
+			withChecksum("$GNGGA,092750.000,5321.6802,N,00630.3372,W,1,8,1.03,61.7,M,55.2,M,,") + "\r\n",
+			"09:27:50 53.361337 -6.505620 1 8 1.03 61.7 55.2", "",
+		},
+		{
+			withChecksum("$GPGGA,,,,,,0,00,99.99,,,,,,"),
+			"00:00:00 0.000000 0.000000 0 0 99.99 0 0", "",
+		},
+		{"GPGGA,123519*47", "", `1:1: expected "$", got "G"`},
+		{"$GPGGA,123519", "", `1:14: missing checksum`},
+		{
+			"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48", "",
+			`1:64: invalid checksum "48", computed "47"`,
+		},
+		{withChecksum("$GPRMC,123519"), "", `1:2: expected GGA sentence, got "GPRMC"`},
+		{withChecksum("$GPGGA,123519"), "", `1:14: expected at least 14 fields, got 1`},
+		{
+			withChecksum("$GPGGA,123519,4807.038,X,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"), "",
+			`1:24: invalid latitude hemisphere "X"`,
+		},
+		{
+			withChecksum("$GPGGA,127519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"), "",
+			`1:8: invalid time "127519"`,
+		},
+		{
+			withChecksum("$GPGGA,123519,4807.038,N,01131.000,E,x,08,0.9,545.4,M,46.9,M,,"), "",
+			`1:38: invalid quality "x"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			g, err := ParseGGA(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			gga := fmt.Sprintf("%v %.6f %.6f %v %v %v %v %v", g.Time.Format("15:04:05"),
+				g.Lat.Degrees(), g.Lon.Degrees(), g.Quality, g.Satellites, g.HDOP, g.Alt, g.GeoidSep)
+			if gga != test.gga {
+				t.Errorf("\n have: %v \n want: %v", gga, test.gga)
+			}
+		})
+	}
+}
+
+func TestParseRMC(t *testing.T) {
+	tests := []struct {
+		input string
+		rmc   string
+		err   string
+	}{
+		{
+			"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A",
+			"1994-03-23T12:35:19Z true 48.117300 11.516667 22.4 84.4 -3.1", "",
+		},
+		{
+			withChecksum("$GNRMC,001031.00,A,4404.13993,N,12118.86023,W,0.146,,100117,,,A"),
+			"2017-01-10T00:10:31Z true 44.068999 -121.314337 0.146 0 0", "",
+		},
+		{
+			withChecksum("$GPRMC,,V,,,,,,,,,,N"),
+			"0001-01-01T00:00:00Z false 0.000000 0.000000 0 0 0", "",
+		},
+		{
+			withChecksum("$GPRMC,123519,X,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W"), "",
+			`1:15: invalid status "X"`,
+		},
+		{
+			withChecksum("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,320394,003.1,W"), "",
+			`1:52: invalid date "320394"`,
+		},
+		{
+			withChecksum("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,N"), "",
+			`1:65: invalid magnetic variation direction "N"`,
+		},
+		{
+			withChecksum("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,1e1000000,230394,003.1,W"), "",
+			`1:46: invalid course "1e1000000"`,
+		},
+		{
+			withChecksum("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,1/3,W"), "",
+			`1:59: invalid magnetic variation "1/3"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, err := ParseRMC(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			rmc := fmt.Sprintf("%v %v %.6f %.6f %v %v %v", r.Time.Format("2006-01-02T15:04:05Z07:00"), r.Valid,
+				r.Lat.Degrees(), r.Lon.Degrees(), r.Speed, r.Course.Degrees(), r.MagVar.Degrees())
+			if rmc != test.rmc {
+				t.Errorf("\n have: %v \n want: %v", rmc, test.rmc)
+			}
+		})
+	}
+}
+
+func TestParseGLL(t *testing.T) {
+	tests := []struct {
+		input string
+		gll   string
+		err   string
+	}{
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", "49.274167 -123.185333 22:54:44 true", ""},
+		{withChecksum("$GPGLL,3751.65,S,14507.36,E"), "", `1:28: expected at least 6 fields, got 4`},
+		{withChecksum("$GPGLL,,,,,,V,N"), "0.000000 0.000000 00:00:00 false", ""},
+		{withChecksum("$GPGLL,4916.45,N,12311.12,N,225444,A,"), "", `1:27: invalid longitude hemisphere "N"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			g, err := ParseGLL(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			gll := fmt.Sprintf("%.6f %.6f %v %v", g.Lat.Degrees(), g.Lon.Degrees(), g.Time.Format("15:04:05"), g.Valid)
+			if gll != test.gll {
+				t.Errorf("\n have: %v \n want: %v", gll, test.gll)
+			}
+		})
+	}
+}
+
+func withChecksum(v string) string {
+	var sum byte
+	for i := 1; i < len(v); i++ {
+		sum ^= v[i]
+	}
+	return fmt.Sprintf("%v*%02X", v, sum)
+}
@@ -2,7 +2,10 @@ package dms
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/blackchip-org/scan"
 )
@@ -20,7 +23,7 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%v: %v", e.Pos, e.Message)
 }
 
-var stateMachine = []func(*scan.Runner, *Fields) (int, error){
+var stateMachine = []func(*scan.Runner, *Context, *Fields, *fieldTokens) (int, error){
 	parseSign,       // S0
 	parseDegNum,     // S1
 	parseDegIntSym,  // S2
@@ -28,6 +31,8 @@ var stateMachine = []func(*scan.Runner, *Fields) (int, error){
 	parseMinNum,     // S4
 	parseSecNum,     // S5
 	parseHemi,       // S6
+	parseSepMinNum,  // S7
+	parseSepSecNum,  // S8
 }
 
 type Parser struct {
@@ -43,72 +48,201 @@ func NewDefaultParser() *Parser {
 	return NewParser(NewContext())
 }
 
-func (p *Parser) ParseFields(v string) (Fields, error) {
+type fieldTokens struct {
+	Start scan.Token
+	Deg   scan.Token
+	Min   scan.Token
+	Hemi  scan.Token
+}
+
+func parseFields(r *scan.Runner, ctx *Context) (Fields, fieldTokens, error) {
 	var a Fields
-	p.scanner.InitFromString("", v)
-	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+	toks := fieldTokens{Start: r.This}
 
 	var state int
 	var err error
 	for {
+		switch state {
+		case 1:
+			toks.Deg = r.This
+		case 6:
+			toks.Hemi = r.This
+		}
 		parse := stateMachine[state]
-		state, err = parse(r, &a)
+		state, err = parse(r, ctx, &a, &toks)
 		if err != nil {
-			return Fields{}, err
+			return Fields{}, fieldTokens{}, err
 		}
 		if state == -1 {
 			break
 		}
 	}
+	if a.Hemi == "+" || a.Hemi == "-" {
+		toks.Hemi = toks.Start
+	}
+	return a, toks, nil
+}
+
+func (p *Parser) scanFields(v string) (Fields, fieldTokens, error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, p.ctx.RuleSet)
+
+	a, toks, err := parseFields(r, p.ctx)
+	if err != nil {
+		return Fields{}, fieldTokens{}, err
+	}
 
 	tok := r.This
 	if !tok.IsEndOfText() {
-		return Fields{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+		return Fields{}, fieldTokens{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
 	}
 
-	return a, nil
+	return a, toks, nil
+}
+
+func (p *Parser) ParseFields(v string) (Fields, error) {
+	a, _, err := p.scanFields(v)
+	return a, err
 }
 
 func (p *Parser) Parse(v string) (Angle, error) {
-	var err error
+	return p.ParseAxis(v, NoAxis)
+}
+
+func (p *Parser) ParseLat(v string) (Angle, error) {
+	return p.ParseAxis(v, LatAxis)
+}
+
+func (p *Parser) ParseLon(v string) (Angle, error) {
+	return p.ParseAxis(v, LonAxis)
+}
 
-	parsed, err := p.ParseFields(v)
+func (p *Parser) ParseHours(v string) (Angle, error) {
+	parsed, toks, err := p.scanFields(v)
 	if err != nil {
 		return Angle{}, err
 	}
+	if parsed.DegSym != "" && !parsed.Hours {
+		return Angle{}, NewError(toks.Deg, "expected hours, got %v", scan.Quote(parsed.String()))
+	}
+	parsed.Hours = true
+	return parsed.axisAngle(toks, NoAxis)
+}
 
-	var deg, min, sec float64
-	if parsed.Deg != "" {
-		deg, err = strconv.ParseFloat(parsed.Deg, 64)
-		if err != nil {
-			return Angle{}, fmt.Errorf("invalid degrees: %v", parsed.Deg)
+func (p *Parser) ParseAxis(v string, ax Axis) (Angle, error) {
+	parsed, toks, err := p.scanFields(v)
+	if err != nil {
+		return Angle{}, err
+	}
+	return parsed.axisAngle(toks, ax)
+}
+
+func (f Fields) axisAngle(toks fieldTokens, ax Axis) (Angle, error) {
+	if hax := hemiAxis(f.Hemi); ax != NoAxis && hax != NoAxis && hax != ax {
+		return Angle{}, NewError(toks.Hemi, "invalid %v hemisphere %v", ax, scan.Quote(toks.Hemi.Lit))
+	}
+	if f.Hours && ax == LatAxis {
+		return Angle{}, NewError(toks.Deg, "hours not allowed for %v", ax)
+	}
+	a, err := f.angle()
+	if err != nil {
+		return Angle{}, err
+	}
+	// Hours are limited to 24h. In place of a longitude they are a right
+	// ascension, which is kept as is in [0h, 24h).
+	if f.Hours {
+		if ax == LonAxis {
+			if a.Sign() < 0 || a.rat().Cmp(rat360) >= 0 {
+				return Angle{}, NewError(toks.Deg, "right ascension out of range: %v", scan.Quote(f.String()))
+			}
+			return a, nil
 		}
+		if a.Abs().rat().Cmp(rat360) > 0 {
+			return Angle{}, NewError(toks.Deg, "hours out of range: %v", scan.Quote(f.String()))
+		}
+		return a, nil
 	}
-	if parsed.Min != "" {
-		min, err = strconv.ParseFloat(parsed.Min, 64)
-		if err != nil {
-			return Angle{}, fmt.Errorf("invalid minutes: %v", parsed.Min)
+	if !ax.inRange(a) {
+		return Angle{}, NewError(toks.Deg, "%v", ax.rangeError(f.String()))
+	}
+	return a, nil
+}
+
+func (f Fields) angle() (Angle, error) {
+	var deg, min, sec big.Rat
+	if f.Deg != "" {
+		if !setDecimal(&deg, f.Deg) {
+			return Angle{}, fmt.Errorf("invalid degrees: %v", f.Deg)
 		}
 	}
-	if parsed.Sec != "" {
-		sec, err = strconv.ParseFloat(parsed.Sec, 64)
-		if err != nil {
-			return Angle{}, fmt.Errorf("invalid seconds: %v", parsed.Sec)
+	if f.Min != "" {
+		if !setDecimal(&min, f.Min) {
+			return Angle{}, fmt.Errorf("invalid minutes: %v", f.Min)
+		}
+	}
+	if f.Sec != "" {
+		if !setDecimal(&sec, f.Sec) {
+			return Angle{}, fmt.Errorf("invalid seconds: %v", f.Sec)
 		}
 	}
-	switch parsed.Hemi {
+	var neg bool
+	switch f.Hemi {
 	case NorthType, EastType, "+", "":
 		// good
 	case SouthType, WestType, "-":
-		deg = deg * -1
+		neg = true
 	default:
-		return Angle{}, fmt.Errorf("invalid hemisphere: %v", parsed.Hemi)
+		return Angle{}, fmt.Errorf("invalid hemisphere: %v", f.Hemi)
+	}
+	if f.Unit != DegUnit {
+		deg.Quo(&deg, unitScale(f.Unit))
 	}
-	return NewAngle(deg, min, sec), nil
+	if f.Hours {
+		deg.Mul(&deg, rat15)
+		min.Mul(&min, rat15)
+		sec.Mul(&sec, rat15)
+	}
+	return newAngleRat(neg, &deg, &min, &sec), nil
+}
+
+// setDecimal sets r to the value of an unsigned decimal number such as
+// "12" or "12.5". Other forms accepted by big.Rat, such as "1e1000000" or
+// "1/3", are rejected.
+func setDecimal(r *big.Rat, v string) bool {
+	digits, dots := 0, 0
+	for _, ch := range v {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits++
+		case ch == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	if digits == 0 || dots > 1 {
+		return false
+	}
+	_, ok := r.SetString(v)
+	return ok
+}
+
+func typeUnit(t string) Unit {
+	switch t {
+	case GradType:
+		return GradUnit
+	case MilType:
+		return MilUnit
+	case RadType:
+		return RadUnit
+	case TurnType:
+		return TurnUnit
+	}
+	return DegUnit
 }
 
 // S0
-func parseSign(r *scan.Runner, a *Fields) (int, error) {
+func parseSign(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case "+":
@@ -122,7 +256,7 @@ func parseSign(r *scan.Runner, a *Fields) (int, error) {
 }
 
 // S1
-func parseDegNum(r *scan.Runner, a *Fields) (int, error) {
+func parseDegNum(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case IntType:
@@ -142,35 +276,64 @@ func parseDegNum(r *scan.Runner, a *Fields) (int, error) {
 		r.Scan()
 		return 3, nil
 	}
-	return -1, NewError(tok, "expected degree, got %v", scan.Quote(tok.Lit))
+	return -1, errExpected(tok, "degree")
 }
 
 // S2
-func parseDegIntSym(r *scan.Runner, a *Fields) (int, error) {
+func parseDegIntSym(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case DegType:
 		a.DegSym = tok.Val
 		r.Scan()
 		return 4, nil
+	case HourType:
+		a.DegSym = tok.Val
+		a.Hours = true
+		r.Scan()
+		return 4, nil
+	case GradType, MilType, RadType, TurnType:
+		a.DegSym = tok.Val
+		a.Unit = typeUnit(tok.Type)
+		r.Scan()
+		return 6, nil
+	}
+	if ctx.Seps != "" {
+		return 7, nil
 	}
 	return -1, nil
 }
 
 // S3
-func parseRealIntSym(r *scan.Runner, a *Fields) (int, error) {
+func parseRealIntSym(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case DegType:
 		a.DegSym = tok.Val
 		r.Scan()
 		return 6, nil
+	case HourType:
+		a.DegSym = tok.Val
+		a.Hours = true
+		r.Scan()
+		return 6, nil
+	case GradType, MilType, RadType, TurnType:
+		a.DegSym = tok.Val
+		a.Unit = typeUnit(tok.Type)
+		r.Scan()
+		return 6, nil
+	}
+	if ctx.Seps != "" {
+		if tok.Type == SepType {
+			return -1, NewError(tok, "only the last field may have a fraction")
+		}
+		return 6, nil
 	}
 	return -1, nil
 }
 
 // S4
-func parseMinNum(r *scan.Runner, a *Fields) (int, error) {
+func parseMinNum(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case IntType:
@@ -182,7 +345,7 @@ func parseMinNum(r *scan.Runner, a *Fields) (int, error) {
 
 		tok := r.Scan()
 		if tok.Type != MinType {
-			return -1, NewError(tok, "expected minute symbol, got %v", scan.Quote(tok.Lit))
+			return -1, errExpected(tok, "minute symbol")
 		}
 		a.MinSym = tok.Val
 		r.Scan()
@@ -196,7 +359,7 @@ func parseMinNum(r *scan.Runner, a *Fields) (int, error) {
 
 		tok := r.Scan()
 		if tok.Type != MinType {
-			return -1, NewError(tok, "expected minute symbol, got %v", scan.Quote(tok.Lit))
+			return -1, errExpected(tok, "minute symbol")
 		}
 		a.MinSym = tok.Val
 		r.Scan()
@@ -206,7 +369,7 @@ func parseMinNum(r *scan.Runner, a *Fields) (int, error) {
 }
 
 // S5
-func parseSecNum(r *scan.Runner, a *Fields) (int, error) {
+func parseSecNum(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	switch tok.Type {
 	case IntType:
@@ -229,7 +392,7 @@ func parseSecNum(r *scan.Runner, a *Fields) (int, error) {
 
 	tok = r.This
 	if tok.Type != SecType {
-		return -1, NewError(tok, "expected second symbol, got %v", scan.Quote(tok.Lit))
+		return -1, errExpected(tok, "second symbol")
 	}
 	a.SecSym = tok.Val
 	r.Scan()
@@ -238,7 +401,7 @@ func parseSecNum(r *scan.Runner, a *Fields) (int, error) {
 }
 
 // S6
-func parseHemi(r *scan.Runner, a *Fields) (int, error) {
+func parseHemi(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
 	tok := r.This
 	var hemi string
 	switch tok.Type {
@@ -256,6 +419,9 @@ func parseHemi(r *scan.Runner, a *Fields) (int, error) {
 		r.Scan()
 	}
 
+	if hemi != "" && a.Hours {
+		return -1, NewError(tok, "hemisphere %v not allowed with hours", scan.Quote(hemi))
+	}
 	if hemi != "" && a.Hemi != "" {
 		return -1, NewError(tok, "only one of %v or %v are allowed", scan.Quote(a.Hemi), scan.Quote(hemi))
 	}
@@ -264,3 +430,105 @@ func parseHemi(r *scan.Runner, a *Fields) (int, error) {
 	}
 	return -1, nil
 }
+
+// scanSep returns the separator between prev and the next field when fields
+// do not have unit symbols. A space is returned when the fields are separated
+// by whitespace alone. An empty string is returned if there is no next field.
+func scanSep(r *scan.Runner, ctx *Context, a *Fields, prev scan.Token) (string, error) {
+	tok := r.This
+	var sep string
+	switch {
+	case ctx.isSep(tok):
+		sep = tok.Val
+		// With whitespace as a separator, "40 -30" could be a sign
+		// instead of a separator
+		if (sep == "+" || sep == "-") && strings.ContainsRune(ctx.Seps, ' ') && !adjacent(prev, tok) {
+			return "", NewError(tok, "ambiguous separator or sign %v", scan.Quote(sep))
+		}
+		r.Scan()
+	case (tok.Type == IntType || tok.Type == RealType) && strings.ContainsRune(ctx.Seps, ' '):
+		sep = " "
+	default:
+		return "", nil
+	}
+	if a.Sep != "" && sep != a.Sep {
+		return "", NewError(tok, "mixed separators %v and %v", scan.Quote(a.Sep), scan.Quote(sep))
+	}
+	a.Sep = sep
+	return sep, nil
+}
+
+// errExpected reports that tok is not what was expected. A word that is
+// not known or a badly grouped number is reported as unexpected.
+func errExpected(tok scan.Token, what string) error {
+	if tok.Type == WordType || tok.Type == InvalidType {
+		return NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+	}
+	return NewError(tok, "expected %v, got %v", what, scan.Quote(tok.Lit))
+}
+
+// adjacent returns true if nothing is between the two tokens
+func adjacent(prev scan.Token, next scan.Token) bool {
+	end := prev.Pos
+	end.Col += utf8.RuneCountInString(prev.Lit)
+	return end == next.Pos
+}
+
+// S7
+func parseSepMinNum(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
+	sep, err := scanSep(r, ctx, a, toks.Deg)
+	if err != nil || sep == "" {
+		return 6, err
+	}
+
+	tok := r.This
+	switch tok.Type {
+	case IntType:
+		min, err := strconv.ParseInt(tok.Val, 10, 64)
+		if err != nil || min >= 60 {
+			return -1, NewError(tok, "invalid minute %v", scan.Quote(tok.Lit))
+		}
+		a.Min = tok.Val
+		toks.Min = tok
+		r.Scan()
+		return 8, nil
+	case RealType:
+		min, err := strconv.ParseFloat(tok.Val, 64)
+		if err != nil || min >= 60 {
+			return -1, NewError(tok, "invalid minute %v", scan.Quote(tok.Lit))
+		}
+		a.Min = tok.Val
+		if tok := r.Scan(); tok.Type == SepType {
+			return -1, NewError(tok, "only the last field may have a fraction")
+		}
+		return 6, nil
+	}
+	return -1, errExpected(tok, "minute")
+}
+
+// S8
+func parseSepSecNum(r *scan.Runner, ctx *Context, a *Fields, toks *fieldTokens) (int, error) {
+	sep, err := scanSep(r, ctx, a, toks.Min)
+	if err != nil || sep == "" {
+		return 6, err
+	}
+
+	tok := r.This
+	switch tok.Type {
+	case IntType:
+		sec, err := strconv.ParseInt(tok.Val, 10, 64)
+		if err != nil || sec >= 60 {
+			return -1, NewError(tok, "invalid second %v", scan.Quote(tok.Lit))
+		}
+	case RealType:
+		sec, err := strconv.ParseFloat(tok.Val, 64)
+		if err != nil || sec >= 60 {
+			return -1, NewError(tok, "invalid second %v", scan.Quote(tok.Lit))
+		}
+	default:
+		return -1, errExpected(tok, "second")
+	}
+	a.Sec = tok.Val
+	r.Scan()
+	return 6, nil
+}
@@ -39,6 +39,16 @@ func TestParser(t *testing.T) {
 		{`1°2'3.4"`, Fields{Deg: "1", DegSym: "°", Min: "2", MinSym: "'", Sec: "3.4", SecSym: `"`}, ""},
 		{`1°2'3.4"S`, Fields{Deg: "1", DegSym: "°", Min: "2", MinSym: "'", Sec: "3.4", SecSym: `"`, Hemi: "S"}, ""},
 		{`9223372036854775807`, Fields{Deg: "9223372036854775807"}, ""},
+		{`14h`, Fields{Deg: "14", DegSym: "h", Hours: true}, ""},
+		{`1.5h`, Fields{Deg: "1.5", DegSym: "h", Hours: true}, ""},
+		{`14h29m42.9s`, Fields{Deg: "14", DegSym: "h", Min: "29", MinSym: "m", Sec: "42.9", SecSym: "s", Hours: true}, ""},
+		{`14ʰ 29ᵐ 42.9ˢ`, Fields{Deg: "14", DegSym: "ʰ", Min: "29", MinSym: "ᵐ", Sec: "42.9", SecSym: "ˢ", Hours: true}, ""},
+		{`-1h`, Fields{Hemi: "-", Deg: "1", DegSym: "h", Hours: true}, ""},
+		{`100gon`, Fields{Deg: "100", DegSym: "gon", Unit: GradUnit}, ""},
+		{`100 grad N`, Fields{Deg: "100", DegSym: "grad", Unit: GradUnit, Hemi: "N"}, ""},
+		{`-1600 mil`, Fields{Hemi: "-", Deg: "1600", DegSym: "mil", Unit: MilUnit}, ""},
+		{`0.25tr`, Fields{Deg: "0.25", DegSym: "tr", Unit: TurnUnit}, ""},
+		{`1.5 rad`, Fields{Deg: "1.5", DegSym: "rad", Unit: RadUnit}, ""},
 
 		{`x`, Fields{}, `1:1: expected degree, got "x"`},
 		{`+`, Fields{}, `1:2: expected degree, got ""`},
@@ -58,6 +68,13 @@ func TestParser(t *testing.T) {
 		{`1°59'60.1"`, Fields{}, `1:6: invalid second "60.1"`},
 		{`-1°2'3.4"N`, Fields{}, `1:10: only one of "-" or "N" are allowed`},
 		{`+1°2'3.4"S`, Fields{}, `1:10: only one of "+" or "S" are allowed`},
+		{`14h29m42.9sN`, Fields{}, `1:12: hemisphere "N" not allowed with hours`},
+		{`1°2mi`, Fields{}, `1:4: unexpected "mi"`},
+		{`30°15min`, Fields{}, `1:6: unexpected "min"`},
+		{`30°15'20mils`, Fields{}, `1:9: unexpected "mils"`},
+		{`30min`, Fields{}, `1:3: unexpected "min"`},
+		{`30 rads`, Fields{}, `1:4: unexpected "rads"`},
+		{`1gon 2′`, Fields{}, `1:6: unexpected "2"`},
 	}
 
 	for _, test := range tests {
@@ -78,6 +95,214 @@ func TestParser(t *testing.T) {
 	}
 }
 
+func TestFieldsAngle(t *testing.T) {
+	tests := []struct {
+		fields Fields
+		deg    string
+		err    string
+	}{
+		{Fields{Deg: "1", Min: "30"}, "1.500000", ""},
+		{Fields{Deg: "1.5", Hemi: "S"}, "-1.500000", ""},
+		{Fields{Deg: "1e1000000"}, "", "invalid degrees: 1e1000000"},
+		{Fields{Deg: "1", Min: "1/3"}, "", "invalid minutes: 1/3"},
+		{Fields{Deg: "1", Min: "2", Sec: "-3"}, "", "invalid seconds: -3"},
+		{Fields{Deg: "1.2.3"}, "", "invalid degrees: 1.2.3"},
+		{Fields{Deg: "."}, "", "invalid degrees: ."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fields.String(), func(t *testing.T) {
+			a, err := test.fields.angle()
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestParseSep(t *testing.T) {
+	tests := []struct {
+		input string
+		angle Fields
+		err   string
+	}{
+		{`40 26 46.3 N`, Fields{Deg: "40", Min: "26", Sec: "46.3", Hemi: "N", Sep: " "}, ""},
+		{`40:26:46.3`, Fields{Deg: "40", Min: "26", Sec: "46.3", Sep: ":"}, ""},
+		{`40-26-46.123N`, Fields{Deg: "40", Min: "26", Sec: "46.123", Hemi: "N", Sep: "-"}, ""},
+		{`-40-26-46`, Fields{Hemi: "-", Deg: "40", Min: "26", Sec: "46", Sep: "-"}, ""},
+		{`40 : 26.5`, Fields{Deg: "40", Min: "26.5", Sep: ":"}, ""},
+		{`40 26`, Fields{Deg: "40", Min: "26", Sep: " "}, ""},
+		{`40 N`, Fields{Deg: "40", Hemi: "N"}, ""},
+		{`40.5 S`, Fields{Deg: "40.5", Hemi: "S"}, ""},
+		{`40`, Fields{Deg: "40"}, ""},
+		{`40°26′46″`, Fields{Deg: "40", DegSym: "°", Min: "26", MinSym: "′", Sec: "46", SecSym: "″"}, ""},
+
+		{`40:26 46`, Fields{}, `1:7: mixed separators ":" and " "`},
+		{`40 26-46`, Fields{}, `1:6: mixed separators " " and "-"`},
+		{`40 -30`, Fields{}, `1:4: ambiguous separator or sign "-"`},
+		{`40 - 30`, Fields{}, `1:4: ambiguous separator or sign "-"`},
+		{`40-26 -46`, Fields{}, `1:7: ambiguous separator or sign "-"`},
+		{`40:60:00`, Fields{}, `1:4: invalid minute "60"`},
+		{`40:59:60`, Fields{}, `1:7: invalid second "60"`},
+		{`40:59.5:10`, Fields{}, `1:8: only the last field may have a fraction`},
+		{`40.5:10`, Fields{}, `1:5: only the last field may have a fraction`},
+		{`40:N`, Fields{}, `1:4: expected minute, got "N"`},
+		{`40:26:`, Fields{}, `1:7: expected second, got ""`},
+		{`40 26 46 12`, Fields{}, `1:10: unexpected "12"`},
+		{`40/26`, Fields{}, `1:3: unexpected "/"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewParser(NewSepContext(" :-"))
+			angle, err := p.ParseFields(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if angle != test.angle {
+				t.Errorf("\n have: %+v \n want: %+v", angle, test.angle)
+			}
+		})
+	}
+}
+
+func TestParseSepOnly(t *testing.T) {
+	p := NewParser(NewSepContext(":"))
+	if _, err := p.ParseFields("40 26"); err == nil || err.Error() != `1:4: unexpected "26"` {
+		t.Errorf("\n have err: %v \n want err: %v", err, `1:4: unexpected "26"`)
+	}
+	if _, err := p.ParseFields("40-26"); err == nil || err.Error() != `1:3: unexpected "-"` {
+		t.Errorf("\n have err: %v \n want err: %v", err, `1:3: unexpected "-"`)
+	}
+	a, err := p.ParseLat("91:00")
+	if err == nil || err.Error() != `1:1: latitude out of range: "91:00"` {
+		t.Errorf("\n have: %v \n have err: %v", a, err)
+	}
+}
+
+func TestParseAxis(t *testing.T) {
+	tests := []struct {
+		input string
+		axis  Axis
+		deg   string
+		err   string
+	}{
+		{`95°N`, NoAxis, "95.000000", ""},
+		{`95°E`, NoAxis, "95.000000", ""},
+		{`90°N`, LatAxis, "90.000000", ""},
+		{`-90`, LatAxis, "-90.000000", ""},
+		{`12°30′S`, LatAxis, "-12.500000", ""},
+		{`180°W`, LonAxis, "-180.000000", ""},
+		{`179°59′59.9″E`, LonAxis, "179.999972", ""},
+		{`100 gon`, LatAxis, "90.000000", ""},
+		{`50 gon S`, LatAxis, "-45.000000", ""},
+		{`-3200 mil`, LonAxis, "-180.000000", ""},
+		{`0.75 tr`, NoAxis, "270.000000", ""},
+		{`1.5707963 rad`, LatAxis, "89.999998", ""},
+		{`375`, NoAxis, "375.000000", ""},
+		{`24h`, NoAxis, "360.000000", ""},
+		{`14h`, LonAxis, "210.000000", ""},
+		{`12h`, LonAxis, "180.000000", ""},
+		{`23h59m59.9s`, LonAxis, "359.999583", ""},
+
+		{`95°N`, LatAxis, "", `1:1: latitude out of range: "95° N"`},
+		{`-90.1`, LatAxis, "", `1:2: latitude out of range: "-90.1°"`},
+		{`90°0′1″S`, LatAxis, "", `1:1: latitude out of range: "90° 0′ 1″ S"`},
+		{`180°0′0.1″E`, LonAxis, "", `1:1: longitude out of range: "180° 0′ 0.1″ E"`},
+		{`10°E`, LatAxis, "", `1:4: invalid latitude hemisphere "E"`},
+		{`10° 30′ N`, LonAxis, "", `1:9: invalid longitude hemisphere "N"`},
+		{`120gon N`, LatAxis, "", `1:1: latitude out of range: "120 gon N"`},
+		{`25h`, NoAxis, "", `1:1: hours out of range: "25h"`},
+		{`24h`, LonAxis, "", `1:1: right ascension out of range: "24h"`},
+		{`-1h`, LonAxis, "", `1:2: right ascension out of range: "-1h"`},
+		{`2h`, LatAxis, "", `1:1: hours not allowed for latitude`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			a, err := p.ParseAxis(test.input, test.axis)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		input string
+		deg   string
+		err   string
+	}{
+		{`14h 29m 42.9s`, "217.428750", ""},
+		{`14`, "210.000000", ""},
+		{`-1.5`, "-22.500000", ""},
+		{`24h`, "360.000000", ""},
+
+		{`25h`, "", `1:1: hours out of range: "25h"`},
+		{`-24h0m0.1s`, "", `1:2: hours out of range: "-24h 0m 0.1s"`},
+		{`14°`, "", `1:1: expected hours, got "14°"`},
+		{`100 gon`, "", `1:1: expected hours, got "100 gon"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			a, err := p.ParseHours(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func ExampleParser_ParseLat() {
+	p := NewDefaultParser()
+	_, err := p.ParseLat("95° N")
+	fmt.Println(err)
+
+	// Output:
+	// 1:1: latitude out of range: "95° N"
+}
+
 func ExampleParser_Parse() {
 	p := NewDefaultParser()
 	a, err := p.Parse("1° 3′ 6″ S")
@@ -0,0 +1,323 @@
+package dms
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/blackchip-org/scan"
+)
+
+// Open Location Code as described in
+// https://github.com/google/open-location-code/blob/main/docs/specification.md
+
+const (
+	plusCodeAlphabet    = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator   = '+'
+	plusCodePadding     = '0'
+	plusCodeSepPos      = 8
+	plusCodePairLength  = 10
+	plusCodeMaxLength   = 15
+	plusCodeGridRows    = 5
+	plusCodeGridColumns = 4
+
+	// precision of the final digit in units of 1/8000 degrees
+	plusCodeFinalLatPrecision = 8000 * 5 * 5 * 5 * 5 * 5
+	plusCodeFinalLonPrecision = 8000 * 4 * 4 * 4 * 4 * 4
+)
+
+type PlusCode string
+
+type PlusCodeArea struct {
+	Lat    Angle
+	Lon    Angle
+	LatLo  Angle
+	LonLo  Angle
+	LatHi  Angle
+	LonHi  Angle
+	Length int
+}
+
+func checkPlusCodeLength(length int) error {
+	if length < 2 || length > plusCodeMaxLength || (length < plusCodePairLength && length%2 == 1) {
+		return fmt.Errorf("invalid plus code length: %v", length)
+	}
+	return nil
+}
+
+func ToPlusCode(lat Angle, lon Angle, length int) (PlusCode, error) {
+	if err := checkPlusCodeLength(length); err != nil {
+		return "", err
+	}
+
+	// Work in integer units of the finest grid cell so that the digits are
+	// exact for the rational angle.
+	latVal := scaledFloor(lat.Add(NewAngle(90, 0, 0)), plusCodeFinalLatPrecision)
+	lonVal := scaledFloor(lon.Add(NewAngle(180, 0, 0)), plusCodeFinalLonPrecision)
+	latMax := big.NewInt(180 * plusCodeFinalLatPrecision)
+	lonMax := big.NewInt(360 * plusCodeFinalLonPrecision)
+	if latVal.Sign() < 0 {
+		latVal.SetInt64(0)
+	}
+	if latVal.Cmp(latMax) >= 0 {
+		latVal.Sub(latMax, big.NewInt(1))
+	}
+	lonVal.Mod(lonVal, lonMax)
+	latN, lonN := latVal.Int64(), lonVal.Int64()
+
+	code := make([]byte, plusCodeMaxLength)
+	for i := plusCodeMaxLength - 1; i >= plusCodePairLength; i-- {
+		code[i] = plusCodeAlphabet[(latN%plusCodeGridRows)*plusCodeGridColumns+lonN%plusCodeGridColumns]
+		latN /= plusCodeGridRows
+		lonN /= plusCodeGridColumns
+	}
+	for i := plusCodePairLength - 2; i >= 0; i -= 2 {
+		code[i] = plusCodeAlphabet[latN%20]
+		code[i+1] = plusCodeAlphabet[lonN%20]
+		latN /= 20
+		lonN /= 20
+	}
+
+	var buf strings.Builder
+	buf.Write(code[:plusCodeSepPos])
+	buf.WriteRune(plusCodeSeparator)
+	buf.Write(code[plusCodeSepPos:max(length, plusCodeSepPos)])
+	if length < plusCodeSepPos {
+		str := buf.String()
+		return PlusCode(str[:length] + strings.Repeat(string(plusCodePadding), plusCodeSepPos-length) + string(plusCodeSeparator)), nil
+	}
+	return PlusCode(buf.String()), nil
+}
+
+func scaledFloor(a Angle, scale int64) *big.Int {
+	r := new(big.Rat).Mul(a.rat(), new(big.Rat).SetInt64(scale))
+	// Euclidean division floors since the denominator is positive
+	return new(big.Int).Div(r.Num(), r.Denom())
+}
+
+// checkPlusCode returns the byte offset and description of the first
+// problem found in the code, or -1 if the code is valid.
+func checkPlusCode(v string) (int, string) {
+	if v == "" {
+		return 0, `expected plus code, got ""`
+	}
+	sep := strings.IndexRune(v, plusCodeSeparator)
+	if sep < 0 {
+		return len(v), "missing separator"
+	}
+	if i := strings.LastIndexByte(v, plusCodeSeparator); i != sep {
+		return i, "only one separator is allowed"
+	}
+	if sep > plusCodeSepPos || sep%2 == 1 {
+		return sep, "invalid separator position"
+	}
+
+	pad := strings.IndexByte(v, plusCodePadding)
+	for i, ch := range v {
+		if ch == plusCodeSeparator || (ch == plusCodePadding && pad >= 0) {
+			continue
+		}
+		if !strings.ContainsRune(plusCodeAlphabet, toUpper(ch)) {
+			return i, fmt.Sprintf("invalid plus code character %v", scan.Quote(string(ch)))
+		}
+	}
+
+	if pad >= 0 {
+		switch {
+		case sep < plusCodeSepPos:
+			return pad, "padding is not allowed in a short code"
+		case pad == 0 || pad%2 == 1:
+			return pad, "invalid padding position"
+		case strings.Trim(v[pad:sep], string(plusCodePadding)) != "":
+			return pad + strings.IndexFunc(v[pad:], func(ch rune) bool { return ch != plusCodePadding }), "invalid padding"
+		case (sep-pad)%2 == 1:
+			return pad, "invalid padding length"
+		case sep != len(v)-1:
+			return sep + 1, "no digits are allowed after padding"
+		}
+	}
+	if len(v)-sep-1 == 1 {
+		return sep + 1, "at least two digits are required after the separator"
+	}
+
+	if sep == plusCodeSepPos {
+		if strings.IndexRune(plusCodeAlphabet, toUpper(rune(v[0])))*20 >= 180 {
+			return 0, "latitude out of range"
+		}
+		if len(v) > 1 && strings.IndexRune(plusCodeAlphabet, toUpper(rune(v[1])))*20 >= 360 {
+			return 1, "longitude out of range"
+		}
+	}
+	return -1, ""
+}
+
+// ParsePlusCode checks that v is a full or short plus code and returns it in
+// upper case
+func ParsePlusCode(v string) (PlusCode, error) {
+	if i, msg := checkPlusCode(v); i >= 0 {
+		return "", &Error{Pos: posAt(v, i), Message: msg}
+	}
+	return PlusCode(strings.ToUpper(v)), nil
+}
+
+func (c PlusCode) validate() error {
+	if _, msg := checkPlusCode(string(c)); msg != "" {
+		return fmt.Errorf("invalid plus code %v: %v", scan.Quote(string(c)), msg)
+	}
+	return nil
+}
+
+func (c PlusCode) IsFull() bool {
+	return c.validate() == nil && strings.IndexRune(string(c), plusCodeSeparator) == plusCodeSepPos
+}
+
+func (c PlusCode) IsShort() bool {
+	return c.validate() == nil && strings.IndexRune(string(c), plusCodeSeparator) < plusCodeSepPos
+}
+
+func (c PlusCode) digits() string {
+	v := strings.ToUpper(string(c))
+	v = strings.ReplaceAll(v, string(plusCodeSeparator), "")
+	return strings.TrimRight(v, string(plusCodePadding))
+}
+
+func (c PlusCode) Area() (PlusCodeArea, error) {
+	if err := c.validate(); err != nil {
+		return PlusCodeArea{}, err
+	}
+	if !c.IsFull() {
+		return PlusCodeArea{}, fmt.Errorf("not a full plus code: %v", scan.Quote(string(c)))
+	}
+	digits := c.digits()
+	if len(digits) > plusCodeMaxLength {
+		digits = digits[:plusCodeMaxLength]
+	}
+
+	lat, lon := big.NewRat(-90, 1), big.NewRat(-180, 1)
+	latSize, lonSize := big.NewRat(20, 1), big.NewRat(20, 1)
+	for i := 0; i < len(digits); i++ {
+		d := int64(strings.IndexByte(plusCodeAlphabet, digits[i]))
+		switch {
+		case i < plusCodePairLength && i%2 == 0:
+			if i > 0 {
+				latSize.Quo(latSize, big.NewRat(20, 1))
+			}
+			lat.Add(lat, new(big.Rat).Mul(latSize, big.NewRat(d, 1)))
+		case i < plusCodePairLength:
+			if i > 1 {
+				lonSize.Quo(lonSize, big.NewRat(20, 1))
+			}
+			lon.Add(lon, new(big.Rat).Mul(lonSize, big.NewRat(d, 1)))
+		default:
+			latSize.Quo(latSize, big.NewRat(plusCodeGridRows, 1))
+			lonSize.Quo(lonSize, big.NewRat(plusCodeGridColumns, 1))
+			lat.Add(lat, new(big.Rat).Mul(latSize, big.NewRat(d/plusCodeGridColumns, 1)))
+			lon.Add(lon, new(big.Rat).Mul(lonSize, big.NewRat(d%plusCodeGridColumns, 1)))
+		}
+	}
+
+	latHi := new(big.Rat).Add(lat, latSize)
+	lonHi := new(big.Rat).Add(lon, lonSize)
+	latMid := new(big.Rat).Add(lat, new(big.Rat).Quo(latSize, big.NewRat(2, 1)))
+	lonMid := new(big.Rat).Add(lon, new(big.Rat).Quo(lonSize, big.NewRat(2, 1)))
+	if latMid.Cmp(big.NewRat(90, 1)) > 0 {
+		latMid.SetInt64(90)
+	}
+	if lonMid.Cmp(big.NewRat(180, 1)) > 0 {
+		lonMid.SetInt64(180)
+	}
+	return PlusCodeArea{
+		Lat:    newAngle(latMid),
+		Lon:    newAngle(lonMid),
+		LatLo:  newAngle(lat),
+		LonLo:  newAngle(lon),
+		LatHi:  newAngle(latHi),
+		LonHi:  newAngle(lonHi),
+		Length: len(digits),
+	}, nil
+}
+
+func (c PlusCode) LatLon() (lat Angle, lon Angle, err error) {
+	a, err := c.Area()
+	if err != nil {
+		return Angle{}, Angle{}, err
+	}
+	return a.Lat, a.Lon, nil
+}
+
+// Shorten removes as many leading digits as possible while the code can
+// still be recovered with a reference position near lat and lon.
+func (c PlusCode) Shorten(lat Angle, lon Angle) (PlusCode, error) {
+	a, err := c.Area()
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsRune(string(c), plusCodePadding) {
+		return "", fmt.Errorf("cannot shorten a padded plus code: %v", scan.Quote(string(c)))
+	}
+	lat = clipLat(lat)
+	lon = NewAngle(wrapLon(lon.Degrees()), 0, 0)
+	dlat := a.Lat.Sub(lat).Degrees()
+	dlon := a.Lon.Sub(lon).Degrees()
+	rng := max(math.Abs(dlat), math.Abs(dlon))
+
+	code := strings.ToUpper(string(c))
+	resolutions := []float64{20, 1, 0.05, 0.0025}
+	for i := len(resolutions) - 1; i >= 1; i-- {
+		if rng < resolutions[i]*0.3 {
+			return PlusCode(code[(i+1)*2:]), nil
+		}
+	}
+	return PlusCode(code), nil
+}
+
+// Recover returns the full code nearest to the reference position for a
+// short code. Full codes are returned unchanged.
+func (c PlusCode) Recover(lat Angle, lon Angle) (PlusCode, error) {
+	if err := c.validate(); err != nil {
+		return "", err
+	}
+	if c.IsFull() {
+		return PlusCode(strings.ToUpper(string(c))), nil
+	}
+	lat = clipLat(lat)
+	latDeg, lonDeg := lat.Degrees(), wrapLon(lon.Degrees())
+
+	padding := plusCodeSepPos - strings.IndexRune(string(c), plusCodeSeparator)
+	resolution := math.Pow(20, float64(2-padding/2))
+	half := resolution / 2
+
+	ref, err := ToPlusCode(lat, NewAngle(lonDeg, 0, 0), plusCodePairLength)
+	if err != nil {
+		return "", err
+	}
+	full := PlusCode(string(ref)[:padding] + strings.ToUpper(string(c)))
+	a, err := full.Area()
+	if err != nil {
+		return "", err
+	}
+
+	centerLat, centerLon := a.Lat.Degrees(), a.Lon.Degrees()
+	if latDeg+half < centerLat && centerLat-resolution >= -90 {
+		centerLat -= resolution
+	} else if latDeg-half > centerLat && centerLat+resolution <= 90 {
+		centerLat += resolution
+	}
+	if lonDeg+half < centerLon {
+		centerLon -= resolution
+	} else if lonDeg-half > centerLon {
+		centerLon += resolution
+	}
+	return ToPlusCode(NewAngle(centerLat, 0, 0), NewAngle(centerLon, 0, 0), a.Length)
+}
+
+func clipLat(lat Angle) Angle {
+	switch {
+	case lat.Degrees() > 90:
+		return NewAngle(90, 0, 0)
+	case lat.Degrees() < -90:
+		return NewAngle(-90, 0, 0)
+	}
+	return lat
+}
@@ -0,0 +1,235 @@
+package dms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestToPlusCode(t *testing.T) {
+	tests := []struct {
+		lat    float64
+		lon    float64
+		length int
+		code   PlusCode
+	}{
+		{20.375, 2.775, 6, "7FG49Q00+"},
+		{20.3700625, 2.7821875, 10, "7FG49QCJ+2V"},
+		{20.3701125, 2.782234375, 11, "7FG49QCJ+2VX"},
+		{20.3701135, 2.78223535156, 13, "7FG49QCJ+2VXGJ"},
+		{47.0000625, 8.0000625, 10, "8FVC2222+22"},
+		{-41.2730625, 174.7859375, 10, "4VCPPQGP+Q9"},
+		{0.5, -179.5, 4, "62G20000+"},
+		{-89.5, -179.5, 4, "22220000+"},
+		{20.5, 2.5, 4, "7FG40000+"},
+		{-89.9999375, -179.9999375, 10, "22222222+22"},
+		{0.5, 179.5, 4, "6VGX0000+"},
+		{1, 1, 11, "6FH32222+222"},
+		{90, 1, 4, "CFX30000+"},
+		{92, 1, 4, "CFX30000+"},
+		{90, 1, 10, "CFX3X2X2+X2"},
+		{1, 180, 4, "62H20000+"},
+		{1, 181, 4, "62H30000+"},
+		{37.4220625, -122.0840625, 10, "849VCWC8+R9"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.code), func(t *testing.T) {
+			code, err := ToPlusCode(NewAngle(test.lat, 0, 0), NewAngle(test.lon, 0, 0), test.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code != test.code {
+				t.Errorf("\n have: %v \n want: %v", code, test.code)
+			}
+		})
+	}
+}
+
+func TestToPlusCodeErrors(t *testing.T) {
+	tests := []struct {
+		length int
+		err    string
+	}{
+		{0, "invalid plus code length: 0"},
+		{1, "invalid plus code length: 1"},
+		{7, "invalid plus code length: 7"},
+		{16, "invalid plus code length: 16"},
+	}
+	for _, test := range tests {
+		t.Run(test.err, func(t *testing.T) {
+			_, err := ToPlusCode(Angle{}, Angle{}, test.length)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("\n have err: %v \n want err: %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestPlusCodeArea(t *testing.T) {
+	tests := []struct {
+		code PlusCode
+		area string
+	}{
+		{"7FG49Q00+", "20.35 2.75 20.4 2.8 6"},
+		{"7FG49QCJ+2V", "20.37 2.782125 20.370125 2.78225 10"},
+		{"7FG49QCJ+2VX", "20.3701 2.78221875 20.370125 2.78225 11"},
+		{"7fg49qcj+2vxgj", "20.370113 2.782234375 20.370114 2.78223632813 13"},
+		{"8FVC2222+22", "47 8 47.000125 8.000125 10"},
+		{"CFX30000+", "89 1 90 2 4"},
+		{"849VCWC8+R9", "37.422 -122.084125 37.422125 -122.084 10"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.code), func(t *testing.T) {
+			a, err := test.code.Area()
+			if err != nil {
+				t.Fatal(err)
+			}
+			area := fmt.Sprintf("%.12g %.12g %.12g %.12g %v", a.LatLo.Degrees(), a.LonLo.Degrees(),
+				a.LatHi.Degrees(), a.LonHi.Degrees(), a.Length)
+			if area != test.area {
+				t.Errorf("\n have: %v \n want: %v", area, test.area)
+			}
+		})
+	}
+}
+
+func TestPlusCodeRoundTrip(t *testing.T) {
+	for lat := -90.0; lat <= 90; lat += 7.7 {
+		for lon := -180.0; lon < 180; lon += 13.1 {
+			for _, length := range []int{2, 4, 6, 8, 10, 11, 12, 13, 14, 15} {
+				code, err := ToPlusCode(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0), length)
+				if err != nil {
+					t.Fatal(err)
+				}
+				a, err := code.Area()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if a.Length != length {
+					t.Errorf("%v: have length %v, want %v", code, a.Length, length)
+				}
+				if lat < a.LatLo.Degrees() || lat > a.LatHi.Degrees() ||
+					lon < a.LonLo.Degrees() || lon > a.LonHi.Degrees() {
+					t.Errorf("%v: %v %v outside of area", code, lat, lon)
+				}
+			}
+		}
+	}
+}
+
+func TestParsePlusCode(t *testing.T) {
+	tests := []struct {
+		input string
+		code  PlusCode
+		err   string
+	}{
+		{"8FWC2345+G6", "8FWC2345+G6", ""},
+		{"8fwc2345+g6g", "8FWC2345+G6G", ""},
+		{"8FWC2300+", "8FWC2300+", ""},
+		{"8FWCX400+", "8FWCX400+", ""},
+		{"WC2345+G6g", "WC2345+G6G", ""},
+		{"2345+G6", "2345+G6", ""},
+		{"+2VX", "+2VX", ""},
+
+		{"", "", `1:1: expected plus code, got ""`},
+		{"8FWC2345G6", "", `1:11: missing separator`},
+		{"8FWC2345+G6+", "", `1:12: only one separator is allowed`},
+		{"8FWC23456+G6", "", `1:10: invalid separator position`},
+		{"8FW+", "", `1:4: invalid separator position`},
+		{"8FWC2_45+G6", "", `1:6: invalid plus code character "_"`},
+		{"8FWC2345+G", "", `1:10: at least two digits are required after the separator`},
+		{"8FWC2300+G6", "", `1:10: no digits are allowed after padding`},
+		{"8FWC0300+", "", `1:6: invalid padding`},
+		{"8FWC2000+", "", `1:6: invalid padding position`},
+		{"8F00+", "", `1:3: padding is not allowed in a short code`},
+		{"WC0000+", "", `1:3: padding is not allowed in a short code`},
+		{"WFWC2345+G6", "", `1:1: latitude out of range`},
+		{"8XWC2345+G6", "", `1:2: longitude out of range`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			code, err := ParsePlusCode(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if code != test.code {
+				t.Errorf("\n have: %v \n want: %v", code, test.code)
+			}
+		})
+	}
+}
+
+func TestPlusCodeShortenRecover(t *testing.T) {
+	tests := []struct {
+		full  PlusCode
+		lat   float64
+		lon   float64
+		short PlusCode
+	}{
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625, "+2VX"},
+		{"9C3W9QCJ+2VX", 51.3708675, -1.217765625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3693575, -1.217765625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.218520625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217010625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3852125, -1.217765625, "9QCJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.232865625, "9QCJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.202665625, "9QCJ+2VX"},
+		{"8FJFW222+", 42.899, 9.012, "8FJFW222+"},
+		{"796RXG22+", 14.95125, -23.5001, "796RXG22+"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.full, test.lat, test.lon), func(t *testing.T) {
+			lat, lon := NewAngle(test.lat, 0, 0), NewAngle(test.lon, 0, 0)
+			if test.full[len(test.full)-1] != '+' {
+				short, err := test.full.Shorten(lat, lon)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if short != test.short {
+					t.Errorf("\n have short: %v \n want short: %v", short, test.short)
+				}
+			}
+			full, err := test.short.Recover(lat, lon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if full != test.full {
+				t.Errorf("\n have full: %v \n want full: %v", full, test.full)
+			}
+		})
+	}
+}
+
+func TestPlusCodeRecover(t *testing.T) {
+	tests := []struct {
+		short PlusCode
+		lat   float64
+		lon   float64
+		full  PlusCode
+	}{
+		{"9G8F+6X", 47.4, 8.6, "8FVC9G8F+6X"},
+		{"CWC8+R9", 37.4, -122.1, "849VCWC8+R9"},
+		{"XXXXXX+XX", -81.0, 0.0, "2CXXXXXX+XX"},
+		{"2222+22", 89.6, 0.0, "CFX22222+22"},
+		{"2222+22", 89.6, -179.99, "C2X22222+22"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.short), func(t *testing.T) {
+			full, err := test.short.Recover(NewAngle(test.lat, 0, 0), NewAngle(test.lon, 0, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if full != test.full {
+				t.Errorf("\n have: %v \n want: %v", full, test.full)
+			}
+		})
+	}
+}
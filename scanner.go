@@ -1,32 +1,104 @@
 package dms
 
-import "github.com/blackchip-org/scan"
+import (
+	"strings"
+	"unicode"
+
+	"github.com/blackchip-org/scan"
+)
 
 const (
-	IntType   = scan.IntType
-	RealType  = scan.RealType
-	DegType   = "deg"
-	MinType   = "min"
-	SecType   = "sec"
-	EastType  = "E"
-	NorthType = "N"
-	SouthType = "S"
-	WestType  = "W"
+	IntType     = scan.IntType
+	RealType    = scan.RealType
+	DegType     = "deg"
+	HourType    = "hour"
+	MinType     = "min"
+	SecType     = "sec"
+	EastType    = "E"
+	NorthType   = "N"
+	SouthType   = "S"
+	WestType    = "W"
+	CommaType   = ","
+	SepType     = "sep"
+	GradType    = "grad"
+	MilType     = "mil"
+	RadType     = "rad"
+	TurnType    = "turn"
+	WordType    = "word"
+	InvalidType = "invalid"
 )
 
 var (
 	SignRule  = scan.NewClassRule(scan.IsSign)
 	DegRule   = scan.NewClassRule(scan.Rune('d', '°')).WithType(DegType)
-	MinRule   = scan.NewClassRule(scan.Rune('m', '\'', '′')).WithType(MinType)
-	SecRule   = scan.NewClassRule(scan.Rune('s', '"', '″')).WithType(SecType)
+	HourRule  = scan.NewClassRule(scan.Rune('h', 'ʰ')).WithType(HourType)
+	MinRule   = scan.NewClassRule(scan.Rune('m', '\'', '′', 'ᵐ')).WithType(MinType)
+	SecRule   = scan.NewClassRule(scan.Rune('s', '"', '″', 'ˢ')).WithType(SecType)
 	EastRule  = scan.NewClassRule(scan.Rune('E')).WithType(EastType)
 	NorthRule = scan.NewClassRule(scan.Rune('N')).WithType(NorthType)
 	SouthRule = scan.NewClassRule(scan.Rune('S')).WithType(SouthType)
 	WestRule  = scan.NewClassRule(scan.Rune('W')).WithType(WestType)
+	CommaRule = scan.NewClassRule(scan.Rune(',')).WithType(CommaType)
+	UnitRule  = NewWordRule(unitWords)
 )
 
+var unitWords = map[string]string{
+	"gon":  GradType,
+	"grad": GradType,
+	"mil":  MilType,
+	"rad":  RadType,
+	"tr":   TurnType,
+}
+
+// WordRule scans a run of letters that starts like one of its words. The
+// scanner only looks ahead one rune so the first two letters decide if the
+// rule applies; this leaves single letters such as "m" and "N" to the
+// class rules. Words may contain other runes, such as "с. ш.", and are
+// read for as long as the text is the start of a word. A run that is not
+// one of the words has WordType so that it cannot be mistaken for a unit
+// or hemisphere.
+type WordRule struct {
+	words    map[string]string
+	prefixes map[string]bool
+}
+
+func NewWordRule(words map[string]string) WordRule {
+	r := WordRule{words: words, prefixes: make(map[string]bool)}
+	for w := range words {
+		rs := []rune(w)
+		for i := 1; i <= len(rs); i++ {
+			r.prefixes[string(rs[:i])] = true
+		}
+	}
+	return r
+}
+
+func (r WordRule) Eval(s *scan.Scanner) bool {
+	if !r.prefixes[string([]rune{s.This, s.Next})] {
+		return false
+	}
+	for r.prefixes[s.Lit.String()+string(s.This)] {
+		s.Keep()
+	}
+	for unicode.IsLetter(s.This) {
+		s.Keep()
+	}
+	lit := s.Lit.String()
+	if t, ok := r.words[lit]; ok {
+		s.Type = t
+	} else {
+		s.Type = WordType
+	}
+	return true
+}
+
+func NewSepRule(seps string) scan.ClassRule {
+	return scan.NewClassRule(scan.Rune([]rune(seps)...)).WithType(SepType)
+}
+
 type Context struct {
 	RuleSet scan.RuleSet
+	Seps    string
 }
 
 func NewContext() *Context {
@@ -35,8 +107,202 @@ func NewContext() *Context {
 		scan.SkipSpaceRule,
 		scan.RealRule,
 		SignRule,
-		DegRule, MinRule, SecRule,
+		UnitRule,
+		DegRule, HourRule, MinRule, SecRule,
 		EastRule, NorthRule, SouthRule, WestRule,
+		CommaRule,
 	)
 	return c
 }
+
+// NewSepContext returns a context that also accepts fields without unit
+// symbols, such as "40:26:46.3", when they are separated by one of the
+// runes in seps. A space in seps allows fields separated by whitespace
+// alone.
+func NewSepContext(seps string) *Context {
+	c := &Context{Seps: seps}
+	c.RuleSet = scan.NewRuleSet(
+		scan.SkipSpaceRule,
+		scan.RealRule,
+		SignRule,
+		UnitRule,
+		DegRule, HourRule, MinRule, SecRule,
+		EastRule, NorthRule, SouthRule, WestRule,
+		CommaRule,
+		NewSepRule(seps),
+	)
+	return c
+}
+
+// NewLocaleContext returns a context that reads numbers with the decimal
+// and digit grouping separators of the locale and accepts its hemisphere
+// labels and words. A single letter label replaces the meaning of that
+// letter, so "O" is east for "de" and west for "es". Longer labels and the
+// words are matched as given, in lower case, and in upper case. Fields
+// without unit symbols are accepted when separated by one of the runes in
+// seps as with NewSepContext.
+func NewLocaleContext(l Locale, seps string) *Context {
+	letters := []letterType{
+		{'N', NorthType},
+		{'S', SouthType},
+		{'E', EastType},
+		{'W', WestType},
+	}
+	words := make(map[string]string)
+	for w, t := range unitWords {
+		words[w] = t
+	}
+	addWord := func(w string, t string) {
+		words[w] = t
+		words[strings.ToLower(w)] = t
+		words[strings.ToUpper(w)] = t
+	}
+	for _, h := range []string{NorthType, SouthType, EastType, WestType} {
+		rs := []rune(l.label(h))
+		if len(rs) == 1 {
+			letters = setLetter(letters, rs[0], h)
+		} else {
+			addWord(string(rs), h)
+		}
+	}
+	for w, t := range l.Words {
+		addWord(w, t)
+	}
+
+	rules := []scan.Rule{
+		scan.SkipSpaceRule,
+		NewDecimalRule(l.decimal(), l.group()),
+		SignRule,
+		NewWordRule(words),
+		DegRule, HourRule, MinRule, SecRule,
+	}
+	for _, lt := range letters {
+		rules = append(rules, scan.NewClassRule(scan.Rune(lt.ch)).WithType(lt.t))
+	}
+	rules = append(rules, CommaRule)
+	if seps != "" {
+		rules = append(rules, NewSepRule(seps))
+	}
+	return &Context{RuleSet: scan.NewRuleSet(rules...), Seps: seps}
+}
+
+type letterType struct {
+	ch rune
+	t  string
+}
+
+// setLetter gives the letter a new meaning or adds it to the end
+func setLetter(letters []letterType, ch rune, t string) []letterType {
+	for i, lt := range letters {
+		if lt.ch == ch {
+			letters[i].t = t
+			return letters
+		}
+	}
+	return append(letters, letterType{ch, t})
+}
+
+// NewDecimalRule scans real numbers that use dec as the decimal separator
+// and, if group is not zero, group between thousands. The value of the
+// token always uses a period and has no grouping. A number with groups that
+// are not three digits long has InvalidType.
+func NewDecimalRule(dec rune, group rune) scan.Rule {
+	if dec == '.' && group == 0 {
+		return scan.RealRule
+	}
+	return scan.RuleFunc(func(s *scan.Scanner) bool {
+		if !scan.IsDigit(s.This) {
+			return false
+		}
+		s.Type = IntType
+		valid := true
+		run, groups := 0, 0
+		for {
+			for scan.IsDigit(s.This) {
+				s.Keep()
+				run++
+			}
+			if group == 0 || s.This != group || !scan.IsDigit(s.Next) {
+				break
+			}
+			if run > 3 || (groups > 0 && run != 3) {
+				valid = false
+			}
+			s.Skip()
+			run = 0
+			groups++
+		}
+		if groups > 0 && run != 3 {
+			valid = false
+		}
+		if s.This == dec && scan.IsDigit(s.Next) {
+			s.Type = RealType
+			s.Skip()
+			s.Val.WriteRune('.')
+			for scan.IsDigit(s.This) {
+				s.Keep()
+			}
+		}
+		if !valid {
+			s.Type = InvalidType
+		}
+		return true
+	})
+}
+
+// isSep returns true if the token separates fields without unit symbols.
+// Signs are scanned before separators so a dash is checked by value.
+func (c *Context) isSep(tok scan.Token) bool {
+	switch tok.Type {
+	case SepType:
+		return true
+	case "+", "-":
+		return strings.Contains(c.Seps, tok.Type)
+	}
+	return false
+}
+
+func posAt(src string, offset int) scan.Pos {
+	return newPosTracker(src).at(offset)
+}
+
+// posTracker returns the positions of increasing offsets without scanning
+// the text from the start each time
+type posTracker struct {
+	src string
+	off int
+	pos scan.Pos
+}
+
+func newPosTracker(src string) *posTracker {
+	return &posTracker{src: src, pos: scan.Pos{Line: 1, Col: 1}}
+}
+
+func (t *posTracker) at(offset int) scan.Pos {
+	for _, ch := range t.src[t.off:offset] {
+		if ch == '\n' {
+			t.pos.Line++
+			t.pos.Col = 1
+		} else {
+			t.pos.Col++
+		}
+	}
+	t.off = offset
+	return t.pos
+}
+
+func offsetOf(src string, pos scan.Pos) int {
+	line, col := 1, 1
+	for i, ch := range src {
+		if line == pos.Line && col == pos.Col {
+			return i
+		}
+		if ch == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return len(src)
+}
@@ -0,0 +1,54 @@
+package dms
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+type Storage int
+
+const (
+	DegreesStorage Storage = iota
+	TextStorage
+)
+
+var (
+	SQLStorage   = DegreesStorage
+	SQLFormatter = NewFormatter(SecUnit, -1)
+)
+
+// Scan returns an error for a NULL value so that missing data is not taken
+// as 0°. Scan into a *Angle, or a sql.Null[Angle] with Go 1.22, for nullable
+// columns. A float that is NaN or infinite is also an error.
+func (a *Angle) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return errors.New("cannot scan NULL into Angle")
+	case float64:
+		v2, err := NewAngleUnit(v, DegUnit)
+		if err != nil {
+			return err
+		}
+		*a = v2
+	case int64:
+		*a = NewAngle(float64(v), 0, 0)
+	case []byte:
+		return a.UnmarshalText(v)
+	case string:
+		return a.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Angle", src)
+	}
+	return nil
+}
+
+func (a Angle) Value() (driver.Value, error) {
+	switch SQLStorage {
+	case DegreesStorage:
+		return a.Degrees(), nil
+	case TextStorage:
+		return SQLFormatter.Format(a), nil
+	}
+	return nil, fmt.Errorf("invalid storage: %v", SQLStorage)
+}
@@ -0,0 +1,177 @@
+package dms
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+// fakeDriver stores every value passed to an INSERT in a single column
+// table and returns them in order for a SELECT.
+type fakeDriver struct {
+	rows []driver.Value
+}
+
+type fakeConn struct{ d *fakeDriver }
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+type fakeRows struct {
+	rows []driver.Value
+	i    int
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if !strings.HasPrefix(s.query, "INSERT") {
+		return nil, fmt.Errorf("unsupported query: %v", s.query)
+	}
+	s.c.d.rows = append(s.c.d.rows, args...)
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(s.query, "SELECT") {
+		return nil, fmt.Errorf("unsupported query: %v", s.query)
+	}
+	return &fakeRows{rows: s.c.d.rows}, nil
+}
+
+func (r *fakeRows) Columns() []string { return []string{"angle"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.i]
+	r.i++
+	return nil
+}
+
+var fakeDriverCount int
+
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{}
+	fakeDriverCount++
+	name := fmt.Sprintf("dmsfake%v", fakeDriverCount)
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, d
+}
+
+func TestAngleScan(t *testing.T) {
+	tests := []struct {
+		src any
+		deg string
+		err string
+	}{
+		{float64(1.5), "1.500000", ""},
+		{int64(-12), "-12.000000", ""},
+		{[]byte(`1° 3′ 6″ S`), "-1.051667", ""},
+		{`1d 3m 6s`, "1.051667", ""},
+
+		{nil, "", `cannot scan NULL into Angle`},
+		{math.NaN(), "", `value is not finite: NaN`},
+		{math.Inf(-1), "", `value is not finite: -Inf`},
+		{`1x`, "", `1:2: unexpected "x"`},
+		{true, "", `cannot scan bool into Angle`},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T %v", test.src, test.src), func(t *testing.T) {
+			var a Angle
+			err := a.Scan(test.src)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if err != nil {
+				return
+			}
+			deg := fmt.Sprintf("%.6f", a.Degrees())
+			if deg != test.deg {
+				t.Errorf("\n have: %v \n want: %v", deg, test.deg)
+			}
+		})
+	}
+}
+
+func TestAngleSQL(t *testing.T) {
+	tests := []struct {
+		storage Storage
+		angle   Angle
+		stored  driver.Value
+	}{
+		{DegreesStorage, NewAngle(1, 30, 0), 1.5},
+		{DegreesStorage, NewAngle(-12, 15, 0), -12.25},
+		{TextStorage, NewAngle(1, 3, 6), "1° 3′ 6″"},
+		{TextStorage, NewAngle(-40, 26, 46.5), "-40° 26′ 46.5″"},
+	}
+
+	defer func() { SQLStorage = DegreesStorage }()
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.stored), func(t *testing.T) {
+			SQLStorage = test.storage
+			db, d := openFakeDB(t)
+			defer db.Close()
+
+			if _, err := db.Exec("INSERT INTO angles VALUES (?)", test.angle); err != nil {
+				t.Fatal(err)
+			}
+			if d.rows[0] != test.stored {
+				t.Errorf("\n have stored: %#v \n want stored: %#v", d.rows[0], test.stored)
+			}
+
+			var a Angle
+			if err := db.QueryRow("SELECT angle FROM angles").Scan(&a); err != nil {
+				t.Fatal(err)
+			}
+			have := fmt.Sprintf("%.9f", a.Degrees())
+			want := fmt.Sprintf("%.9f", test.angle.Degrees())
+			if have != want {
+				t.Errorf("\n have: %v \n want: %v", have, want)
+			}
+		})
+	}
+}
+
+func TestAngleSQLNull(t *testing.T) {
+	db, d := openFakeDB(t)
+	defer db.Close()
+	d.rows = []driver.Value{nil}
+
+	var a Angle
+	if err := db.QueryRow("SELECT angle FROM angles").Scan(&a); err == nil {
+		t.Errorf("expected error for NULL")
+	}
+	a2 := &Angle{}
+	if err := db.QueryRow("SELECT angle FROM angles").Scan(&a2); err != nil {
+		t.Fatal(err)
+	}
+	if a2 != nil {
+		t.Errorf("\n have: %v \n want: nil", a2)
+	}
+}
@@ -0,0 +1,59 @@
+package dms
+
+import "math"
+
+const (
+	upsK0            = 0.994
+	upsFalseEasting  = 2000000.0
+	upsFalseNorthing = 2000000.0
+)
+
+// Universal Polar Stereographic projection using the formulas in Snyder,
+// "Map Projections: A Working Manual", USGS Professional Paper 1395, 1987.
+
+func upsScale() float64 {
+	e := math.Sqrt(wgs84F * (2 - wgs84F))
+	return 2 * wgs84A * upsK0 / math.Sqrt(math.Pow(1+e, 1+e)*math.Pow(1-e, 1-e))
+}
+
+func toUPS(lat float64, lon float64) (north bool, x float64, y float64) {
+	north = lat >= 0
+	e := math.Sqrt(wgs84F * (2 - wgs84F))
+	phi, lambda := math.Abs(lat)*pi180, lon*pi180
+	esin := e * math.Sin(phi)
+	t := math.Tan(math.Pi/4-phi/2) / math.Pow((1-esin)/(1+esin), e/2)
+	rho := upsScale() * t
+
+	x = upsFalseEasting + rho*math.Sin(lambda)
+	if north {
+		y = upsFalseNorthing - rho*math.Cos(lambda)
+	} else {
+		y = upsFalseNorthing + rho*math.Cos(lambda)
+	}
+	return
+}
+
+func fromUPS(north bool, x float64, y float64) (lat float64, lon float64) {
+	e := math.Sqrt(wgs84F * (2 - wgs84F))
+	dx, dy := x-upsFalseEasting, y-upsFalseNorthing
+	rho := math.Hypot(dx, dy)
+	t := rho / upsScale()
+
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 20; i++ {
+		esin := e * math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-esin)/(1+esin), e/2))
+		if math.Abs(next-phi) < 1e-14 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+
+	if north {
+		lat, lon = phi/pi180, math.Atan2(dx, -dy)/pi180
+	} else {
+		lat, lon = -phi/pi180, math.Atan2(dx, dy)/pi180
+	}
+	return
+}
@@ -0,0 +1,323 @@
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/blackchip-org/scan"
+)
+
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+
+	utmK0            = 0.9996
+	utmFalseEasting  = 500000.0
+	utmFalseNorthing = 10000000.0
+	utmMinLat        = -80
+	utmMaxLat        = 84
+
+	utmBands = "CDEFGHJKLMNPQRSTUVWXX"
+)
+
+const BandType = "band"
+
+var BandRule = scan.NewClassRule(isBand).WithType(BandType)
+
+var utmRuleSet = scan.NewRuleSet(
+	scan.SkipSpaceRule,
+	scan.RealRule,
+	BandRule,
+)
+
+func isBand(ch rune) bool {
+	ch = toUpper(ch)
+	return ch >= 'C' && ch <= 'X' && ch != 'I' && ch != 'O'
+}
+
+func toUpper(ch rune) rune {
+	if ch >= 'a' && ch <= 'z' {
+		return ch - 'a' + 'A'
+	}
+	return ch
+}
+
+type UTM struct {
+	Zone     int
+	Band     string
+	Easting  float64
+	Northing float64
+}
+
+func (u UTM) IsSouth() bool {
+	return strings.ToUpper(u.Band) < "N"
+}
+
+func (u UTM) String() string {
+	return NewUTMFormatter(0).Format(u)
+}
+
+// Transverse Mercator using the Krüger series to sixth order in n as
+// given in Karney, "Transverse Mercator with an accuracy of a few
+// nanometers", J. Geodesy 85(8), 2011.
+type transverseMercator struct {
+	e     float64
+	scale float64
+	alpha [7]float64
+	beta  [7]float64
+}
+
+var tmWGS84 = newTransverseMercator(wgs84A, wgs84F, utmK0)
+
+func newTransverseMercator(a float64, f float64, k0 float64) transverseMercator {
+	n := f / (2 - f)
+	n2, n3, n4, n5, n6 := n*n, n*n*n, n*n*n*n, n*n*n*n*n, n*n*n*n*n*n
+	tm := transverseMercator{
+		e:     math.Sqrt(f * (2 - f)),
+		scale: k0 * a / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+	}
+	tm.alpha = [7]float64{0,
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+		13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+		61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+		49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+		34729*n5/80640 - 3418889*n6/1995840,
+		212378941 * n6 / 319334400,
+	}
+	tm.beta = [7]float64{0,
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+		n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+		17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+		4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+		4583*n5/161280 - 108847*n6/3991680,
+		20648693 * n6 / 638668800,
+	}
+	return tm
+}
+
+// forward returns the easting and northing, relative to the central
+// meridian and the equator, for the latitude and the longitude difference
+// from the central meridian in radians.
+func (tm transverseMercator) forward(lat float64, dlon float64) (x float64, y float64) {
+	e := tm.e
+	tau := math.Tan(lat)
+	sigma := math.Sinh(e * math.Atanh(e*tau/math.Sqrt(1+tau*tau)))
+	taup := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+	xip := math.Atan2(taup, math.Cos(dlon))
+	etap := math.Asinh(math.Sin(dlon) / math.Sqrt(taup*taup+math.Cos(dlon)*math.Cos(dlon)))
+
+	xi, eta := xip, etap
+	for j := 1; j <= 6; j++ {
+		fj := float64(2 * j)
+		xi += tm.alpha[j] * math.Sin(fj*xip) * math.Cosh(fj*etap)
+		eta += tm.alpha[j] * math.Cos(fj*xip) * math.Sinh(fj*etap)
+	}
+	return tm.scale * eta, tm.scale * xi
+}
+
+func (tm transverseMercator) inverse(x float64, y float64) (lat float64, dlon float64) {
+	e := tm.e
+	xi, eta := y/tm.scale, x/tm.scale
+	xip, etap := xi, eta
+	for j := 1; j <= 6; j++ {
+		fj := float64(2 * j)
+		xip -= tm.beta[j] * math.Sin(fj*xi) * math.Cosh(fj*eta)
+		etap -= tm.beta[j] * math.Cos(fj*xi) * math.Sinh(fj*eta)
+	}
+
+	sinhEtap, sinXip, cosXip := math.Sinh(etap), math.Sin(xip), math.Cos(xip)
+	taup := sinXip / math.Sqrt(sinhEtap*sinhEtap+cosXip*cosXip)
+
+	tau := taup
+	for i := 0; i < 10; i++ {
+		sigma := math.Sinh(e * math.Atanh(e*tau/math.Sqrt(1+tau*tau)))
+		taui := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+		delta := (taup - taui) / math.Sqrt(1+taui*taui) *
+			(1 + (1-e*e)*tau*tau) / ((1 - e*e) * math.Sqrt(1+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+	return math.Atan(tau), math.Atan2(sinhEtap, cosXip)
+}
+
+func utmBand(lat float64) string {
+	i := int(math.Floor(lat/8 + 10))
+	i = max(0, min(i, len(utmBands)-1))
+	return utmBands[i : i+1]
+}
+
+func utmZone(lat float64, lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 1
+	}
+	switch band := utmBand(lat); {
+	case band == "V" && zone == 31 && lon >= 3:
+		zone = 32
+	case band == "X" && zone == 32:
+		zone = 31
+		if lon >= 9 {
+			zone = 33
+		}
+	case band == "X" && zone == 34:
+		zone = 33
+		if lon >= 21 {
+			zone = 35
+		}
+	case band == "X" && zone == 36:
+		zone = 35
+		if lon >= 33 {
+			zone = 37
+		}
+	}
+	return zone
+}
+
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone-1)*6 - 180 + 3
+}
+
+func ToUTM(lat Angle, lon Angle) (UTM, error) {
+	return ToUTMZone(lat, lon, 0)
+}
+
+// ToUTMZone converts to UTM using the given zone instead of the standard
+// zone for the position. A zone of zero selects the standard zone.
+func ToUTMZone(lat Angle, lon Angle, zone int) (UTM, error) {
+	latDeg, lonDeg := lat.Degrees(), wrapLon(lon.Degrees())
+	if latDeg < utmMinLat || latDeg > utmMaxLat {
+		return UTM{}, fmt.Errorf("latitude outside of UTM limits: %v", latDeg)
+	}
+	if zone == 0 {
+		zone = utmZone(latDeg, lonDeg)
+	}
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("invalid zone: %v", zone)
+	}
+	dlon := wrapLon(lonDeg - utmCentralMeridian(zone))
+	x, y := tmWGS84.forward(latDeg*pi180, dlon*pi180)
+	x += utmFalseEasting
+	if latDeg < 0 {
+		y += utmFalseNorthing
+	}
+	return UTM{
+		Zone:     zone,
+		Band:     utmBand(latDeg),
+		Easting:  x,
+		Northing: y,
+	}, nil
+}
+
+func (u UTM) LatLon() (lat Angle, lon Angle, err error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return Angle{}, Angle{}, fmt.Errorf("invalid zone: %v", u.Zone)
+	}
+	if len(u.Band) != 1 || !isBand(rune(u.Band[0])) {
+		return Angle{}, Angle{}, fmt.Errorf("invalid band: %v", u.Band)
+	}
+	x := u.Easting - utmFalseEasting
+	y := u.Northing
+	if u.IsSouth() {
+		y -= utmFalseNorthing
+	}
+	latRad, dlonRad := tmWGS84.inverse(x, y)
+	lonDeg := wrapLon(dlonRad/pi180 + utmCentralMeridian(u.Zone))
+	return NewAngle(latRad/pi180, 0, 0), NewAngle(lonDeg, 0, 0), nil
+}
+
+func wrapLon(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
+
+func (p *Parser) ParseUTM(v string) (UTM, error) {
+	p.scanner.InitFromString("", v)
+	r := scan.NewRunner(&p.scanner, utmRuleSet)
+
+	var u UTM
+	tok := r.This
+	if tok.Type != IntType {
+		return UTM{}, NewError(tok, "expected zone, got %v", scan.Quote(tok.Lit))
+	}
+	zone, err := strconv.Atoi(tok.Val)
+	if err != nil || zone < 1 || zone > 60 {
+		return UTM{}, NewError(tok, "invalid zone %v", scan.Quote(tok.Lit))
+	}
+	u.Zone = zone
+
+	tok = r.Scan()
+	if tok.Type != BandType {
+		return UTM{}, NewError(tok, "expected latitude band, got %v", scan.Quote(tok.Lit))
+	}
+	u.Band = strings.ToUpper(tok.Val)
+
+	tok = r.Scan()
+	if tok.Type != IntType && tok.Type != RealType {
+		return UTM{}, NewError(tok, "expected easting, got %v", scan.Quote(tok.Lit))
+	}
+	u.Easting, err = strconv.ParseFloat(tok.Val, 64)
+	if err != nil || u.Easting < 100000 || u.Easting >= 900000 {
+		return UTM{}, NewError(tok, "invalid easting %v", scan.Quote(tok.Lit))
+	}
+
+	tok = r.Scan()
+	if tok.Type != IntType && tok.Type != RealType {
+		return UTM{}, NewError(tok, "expected northing, got %v", scan.Quote(tok.Lit))
+	}
+	u.Northing, err = strconv.ParseFloat(tok.Val, 64)
+	if err != nil || u.Northing < 0 || u.Northing > utmFalseNorthing {
+		return UTM{}, NewError(tok, "invalid northing %v", scan.Quote(tok.Lit))
+	}
+
+	tok = r.Scan()
+	if !tok.IsEndOfText() {
+		return UTM{}, NewError(tok, "unexpected %v", scan.Quote(tok.Lit))
+	}
+	return u, nil
+}
+
+type UTMFormatter struct {
+	Sep    string
+	Places int
+}
+
+func NewUTMFormatter(places int) UTMFormatter {
+	return UTMFormatter{
+		Sep:    " ",
+		Places: places,
+	}
+}
+
+func (f UTMFormatter) WithSep(sep string) UTMFormatter {
+	f.Sep = sep
+	return f
+}
+
+// Format writes the easting and northing truncated to Places decimal places,
+// as with MGRSFormatter, so that the position written is the corner of the
+// cell that contains it. A negative Places writes them in full.
+func (f UTMFormatter) Format(u UTM) string {
+	return fmt.Sprintf("%v%v%v%v%v%v",
+		u.Zone, u.Band, f.Sep,
+		truncGrid(u.Easting, f.Places), f.Sep,
+		truncGrid(u.Northing, f.Places),
+	)
+}
+
+// truncGrid truncates a grid coordinate to a number of places. The small
+// offset keeps a value that lands a rounding error below a boundary in the
+// right cell.
+func truncGrid(v float64, places int) string {
+	if places < 0 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	scale := math.Pow(10, float64(places))
+	return strconv.FormatFloat(math.Floor((v+1e-6)*scale)/scale, 'f', places, 64)
+}
@@ -0,0 +1,182 @@
+package dms
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestToUTM(t *testing.T) {
+	tests := []struct {
+		lat      Angle
+		lon      Angle
+		zone     int
+		band     string
+		easting  float64
+		northing float64
+	}{
+		{NewAngle(0, 0, 0), NewAngle(0, 0, 0), 31, "N", 166021.443, 0},
+		{NewAngle(1, 0, 0), NewAngle(1, 0, 0), 31, "N", 277438.263, 110597.973},
+		{NewAngle(-1, 0, 0), NewAngle(-1, 0, 0), 30, "M", 722561.737, 9889402.027},
+		{NewAngle(48.8582, 0, 0), NewAngle(2.2945, 0, 0), 31, "U", 448251.795, 5411932.678},
+		{NewAngle(43, 38, 33.24), NewAngle(-79, 23, 13.7), 17, "T", 630084.311, 4833438.549},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.lat.Degrees(), test.lon.Degrees()), func(t *testing.T) {
+			u, err := ToUTM(test.lat, test.lon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Zone != test.zone || u.Band != test.band {
+				t.Errorf("\n have zone: %v%v \n want zone: %v%v", u.Zone, u.Band, test.zone, test.band)
+			}
+			if math.Abs(u.Easting-test.easting) > 0.001 || math.Abs(u.Northing-test.northing) > 0.001 {
+				t.Errorf("\n have: %.3f %.3f \n want: %.3f %.3f", u.Easting, u.Northing, test.easting, test.northing)
+			}
+		})
+	}
+}
+
+func TestUTMZoneExceptions(t *testing.T) {
+	tests := []struct {
+		lat  float64
+		lon  float64
+		zone int
+	}{
+		{60, 2.9, 31},
+		{60, 4, 32},
+		{55.9, 4, 31},
+		{64.1, 4, 31},
+		{75, 3, 31},
+		{75, 8.9, 31},
+		{75, 9, 33},
+		{75, 20.9, 33},
+		{75, 21, 35},
+		{75, 32.9, 35},
+		{75, 33, 37},
+		{71.9, 9, 32},
+		{-75, 9, 32},
+		{0, 180, 1},
+		{0, -180, 1},
+		{0, 179.9, 60},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.lat, test.lon), func(t *testing.T) {
+			u, err := ToUTM(NewAngle(test.lat, 0, 0), NewAngle(test.lon, 0, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Zone != test.zone {
+				t.Errorf("\n have: %v \n want: %v", u.Zone, test.zone)
+			}
+		})
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	for lat := -80.0; lat <= 84; lat += 4.1 {
+		for lon := -180.0; lon < 180; lon += 7.3 {
+			u, err := ToUTM(NewAngle(lat, 0, 0), NewAngle(lon, 0, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat2, lon2, err := u.LatLon()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 1e-9 degrees is about 0.1 mm
+			if math.Abs(lat2.Degrees()-lat) > 1e-9 || math.Abs(lon2.Degrees()-lon) > 1e-9 {
+				t.Errorf("\n have: %v %v \n want: %v %v", lat2.Degrees(), lon2.Degrees(), lat, lon)
+			}
+		}
+	}
+}
+
+func TestToUTMErrors(t *testing.T) {
+	tests := []struct {
+		lat Angle
+		lon Angle
+		err string
+	}{
+		{NewAngle(84, 0, 1), NewAngle(0, 0, 0), "latitude outside of UTM limits: 84.00027777777778"},
+		{NewAngle(-81, 0, 0), NewAngle(0, 0, 0), "latitude outside of UTM limits: -81"},
+	}
+	for _, test := range tests {
+		t.Run(test.err, func(t *testing.T) {
+			_, err := ToUTM(test.lat, test.lon)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("\n have err: %v \n want err: %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestParseUTM(t *testing.T) {
+	tests := []struct {
+		input string
+		utm   UTM
+		err   string
+	}{
+		{`17T 630084 4833438`, UTM{Zone: 17, Band: "T", Easting: 630084, Northing: 4833438}, ""},
+		{`17t 630084.311 4833438.549`, UTM{Zone: 17, Band: "T", Easting: 630084.311, Northing: 4833438.549}, ""},
+		{`  30 M 722561 9889402 `, UTM{Zone: 30, Band: "M", Easting: 722561, Northing: 9889402}, ""},
+
+		{`T 630084 4833438`, UTM{}, `1:1: expected zone, got "T"`},
+		{`61T 630084 4833438`, UTM{}, `1:1: invalid zone "61"`},
+		{`0T 630084 4833438`, UTM{}, `1:1: invalid zone "0"`},
+		{`17I 630084 4833438`, UTM{}, `1:3: expected latitude band, got "I"`},
+		{`17T`, UTM{}, `1:4: expected easting, got ""`},
+		{`17T 930084 4833438`, UTM{}, `1:5: invalid easting "930084"`},
+		{`17T 630084`, UTM{}, `1:11: expected northing, got ""`},
+		{`17T 630084 14833438`, UTM{}, `1:12: invalid northing "14833438"`},
+		{`17T 630084 4833438 1`, UTM{}, `1:20: unexpected "1"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			p := NewDefaultParser()
+			u, err := p.ParseUTM(test.input)
+			errMsg := ""
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != test.err {
+				t.Fatalf("\n have err: %v \n want err: %v", errMsg, test.err)
+			}
+			if u != test.utm {
+				t.Errorf("\n have: %+v \n want: %+v", u, test.utm)
+			}
+		})
+	}
+}
+
+func TestFormatUTM(t *testing.T) {
+	u := UTM{Zone: 17, Band: "T", Easting: 630084.311, Northing: 4833438.549}
+	tests := []struct {
+		f      UTMFormatter
+		result string
+	}{
+		{NewUTMFormatter(0), "17T 630084 4833438"},
+		{NewUTMFormatter(1), "17T 630084.3 4833438.5"},
+		{NewUTMFormatter(2), "17T 630084.31 4833438.54"},
+		{NewUTMFormatter(-1), "17T 630084.311 4833438.549"},
+		{NewUTMFormatter(0).WithSep(","), "17T,630084,4833438"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result := test.f.Format(u)
+			if result != test.result {
+				t.Errorf("\n have: [%v] \n want: [%v]\n", result, test.result)
+			}
+		})
+	}
+
+	// A metre boundary that is a rounding error away is not truncated down
+	u = UTM{Zone: 17, Band: "T", Easting: 630085 - 1e-9, Northing: 4833439 - 1e-9}
+	if result, want := NewUTMFormatter(0).Format(u), "17T 630085 4833439"; result != want {
+		t.Errorf("\n have: [%v] \n want: [%v]\n", result, want)
+	}
+}